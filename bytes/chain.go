@@ -65,6 +65,18 @@ func SepBy[T any, S any](
 	return common.SepBy[byte, int, T](cap, body, sep)
 }
 
+// SepByFold - like SepBy, but combine bytes which returned by body combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value (see common.SepByFold).
+func SepByFold[T any, S any, A any](
+	init A,
+	body common.Combinator[byte, int, T],
+	sep common.Combinator[byte, int, S],
+	f func(A, T) A,
+) common.Combinator[byte, int, A] {
+	return common.SepByFold(init, body, sep, f)
+}
+
 // SepBy1 - read one or more occurrences of byte readed by c combinator,
 // separated by sep combinator.
 // Returns a slice of values returned by p.
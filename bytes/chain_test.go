@@ -353,6 +353,39 @@ func TestSepBy(t *testing.T) {
 	})
 }
 
+func TestSepByFold(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[int]{
+		{
+			comb: SepByFold(
+				0,
+				NotEq("expected not ','", ','),
+				Eq("expected ','", ','),
+				func(acc int, x byte) int { return acc + int(x) },
+			),
+			cases: []testCase[int]{
+				{
+					input:  []byte{},
+					output: 0,
+				},
+				{
+					input:  []byte{1, ',', 2, ',', 3},
+					output: 6,
+				},
+				{
+					input:  []byte{1, ',', 2, ','},
+					output: 3,
+				},
+				{
+					input:  []byte{','},
+					output: 0,
+				},
+			},
+		},
+	})
+}
+
 func TestSepBy1(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,238 @@
+package bytes
+
+import (
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"math/bits"
+
+	"github.com/okneniz/parsec/common"
+	"golang.org/x/exp/constraints"
+)
+
+// Digest - function which computes checksum of data.
+type Digest[S constraints.Unsigned] func(data []byte) S
+
+// CRC16Params - parameters of CRC-16 algorithm.
+// Read more about CRC-16 variants - https://reveng.sourceforge.io/crc-catalogue/16.htm
+type CRC16Params struct {
+	Poly      uint16
+	Init      uint16
+	Reflected bool
+	XorOut    uint16
+}
+
+var (
+	CRC16ARC        = CRC16Params{Poly: 0x8005, Init: 0x0000, Reflected: true}
+	CRC16Modbus     = CRC16Params{Poly: 0x8005, Init: 0xffff, Reflected: true}
+	CRC16CCITTFalse = CRC16Params{Poly: 0x1021, Init: 0xffff}
+	CRC16XModem     = CRC16Params{Poly: 0x1021, Init: 0x0000}
+	CRC16Kermit     = CRC16Params{Poly: 0x1021, Init: 0x0000, Reflected: true}
+	CRC16X25        = CRC16Params{Poly: 0x1021, Init: 0xffff, Reflected: true, XorOut: 0xffff}
+)
+
+// Hash32 - make digest from 32-bit hash function, like crc32.NewIEEE or fnv.New32a.
+func Hash32(newHash func() hash.Hash32) Digest[uint32] {
+	return func(data []byte) uint32 {
+		h := newHash()
+		_, _ = h.Write(data)
+		return h.Sum32()
+	}
+}
+
+// Hash64 - make digest from 64-bit hash function, like fnv.New64a.
+func Hash64(newHash func() hash.Hash64) Digest[uint64] {
+	return func(data []byte) uint64 {
+		h := newHash()
+		_, _ = h.Write(data)
+		return h.Sum64()
+	}
+}
+
+// CRC32 - make digest which computes CRC-32 checksum using table,
+// for example crc32.IEEETable.
+func CRC32(tab *crc32.Table) Digest[uint32] {
+	return func(data []byte) uint32 {
+		return crc32.Checksum(data, tab)
+	}
+}
+
+// Adler32 - make digest which computes Adler-32 checksum.
+func Adler32() Digest[uint32] {
+	return adler32.Checksum
+}
+
+// CRC16 - make digest which computes CRC-16 checksum
+// with passed parameters, for example CRC16CCITTFalse.
+func CRC16(params CRC16Params) Digest[uint16] {
+	var table [256]uint16
+
+	poly := params.Poly
+	if params.Reflected {
+		poly = bits.Reverse16(poly)
+	}
+
+	for i := range table {
+		if params.Reflected {
+			crc := uint16(i)
+
+			for j := 0; j < 8; j++ {
+				if crc&1 != 0 {
+					crc = crc>>1 ^ poly
+				} else {
+					crc >>= 1
+				}
+			}
+
+			table[i] = crc
+		} else {
+			crc := uint16(i) << 8
+
+			for j := 0; j < 8; j++ {
+				if crc&0x8000 != 0 {
+					crc = crc<<1 ^ poly
+				} else {
+					crc <<= 1
+				}
+			}
+
+			table[i] = crc
+		}
+	}
+
+	return func(data []byte) uint16 {
+		crc := params.Init
+
+		for _, b := range data {
+			if params.Reflected {
+				crc = crc>>8 ^ table[byte(crc)^b]
+			} else {
+				crc = crc<<8 ^ table[byte(crc>>8)^b]
+			}
+		}
+
+		return crc ^ params.XorOut
+	}
+}
+
+// Checksum - parse data by body combinator, then parse checksum by sum combinator
+// and compare it with checksum of bytes consumed by body, computed by digest.
+// Apply results of body and sum to compose function and return result of it.
+// If checksums are not equal, returns ParseError with expected and actual values.
+func Checksum[T any, S constraints.Unsigned, M any](
+	errMessage string,
+	digest Digest[S],
+	body common.Combinator[byte, int, T],
+	sum common.Combinator[byte, int, S],
+	compose common.Composer[T, S, M],
+) common.Combinator[byte, int, M] {
+	var null M
+
+	return func(buffer common.Buffer[byte, int]) (M, common.Error[int]) {
+		start := buffer.Position()
+
+		result, err := body(buffer)
+		if err != nil {
+			return null, err
+		}
+
+		data, err := consumed(buffer, start, buffer.Position())
+		if err != nil {
+			return null, err
+		}
+
+		pos := buffer.Position()
+
+		expected, err := sum(buffer)
+		if err != nil {
+			return null, err
+		}
+
+		if err := verify(pos, errMessage, expected, digest(data)); err != nil {
+			return null, err
+		}
+
+		return compose(result, expected), nil
+	}
+}
+
+// ChecksumBefore - parse checksum by sum combinator, then parse data by body combinator
+// and compare checksum with checksum of bytes consumed by body, computed by digest.
+// Apply results of body and sum to compose function and return result of it.
+// If checksums are not equal, returns ParseError with expected and actual values.
+func ChecksumBefore[T any, S constraints.Unsigned, M any](
+	errMessage string,
+	digest Digest[S],
+	sum common.Combinator[byte, int, S],
+	body common.Combinator[byte, int, T],
+	compose common.Composer[T, S, M],
+) common.Combinator[byte, int, M] {
+	var null M
+
+	return func(buffer common.Buffer[byte, int]) (M, common.Error[int]) {
+		pos := buffer.Position()
+
+		expected, err := sum(buffer)
+		if err != nil {
+			return null, err
+		}
+
+		start := buffer.Position()
+
+		result, err := body(buffer)
+		if err != nil {
+			return null, err
+		}
+
+		data, err := consumed(buffer, start, buffer.Position())
+		if err != nil {
+			return null, err
+		}
+
+		if err := verify(pos, errMessage, expected, digest(data)); err != nil {
+			return null, err
+		}
+
+		return compose(result, expected), nil
+	}
+}
+
+// consumed - read again bytes between start and end positions,
+// buffer will be at the end position after it.
+func consumed(
+	buffer common.Buffer[byte, int],
+	start, end int,
+) ([]byte, common.Error[int]) {
+	if err := buffer.Seek(start); err != nil {
+		return nil, common.NewParseError(buffer.Position(), err.Error())
+	}
+
+	data := make([]byte, 0, end-start)
+
+	for i := start; i < end; i++ {
+		b, err := buffer.Read(true)
+		if err != nil {
+			return nil, common.NewParseError(buffer.Position(), err.Error())
+		}
+
+		data = append(data, b)
+	}
+
+	return data, nil
+}
+
+func verify[S constraints.Unsigned](
+	pos int,
+	errMessage string,
+	expected, actual S,
+) common.Error[int] {
+	if expected == actual {
+		return nil
+	}
+
+	return common.NewParseError(
+		pos,
+		fmt.Sprintf("%s: expected %#x, actual %#x", errMessage, expected, actual),
+	)
+}
@@ -0,0 +1,127 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestCRC16(t *testing.T) {
+	t.Parallel()
+
+	// check values from CRC catalogue
+	data := []byte("123456789")
+
+	assert.Equal(t, uint16(0xbb3d), CRC16(CRC16ARC)(data))
+	assert.Equal(t, uint16(0x4b37), CRC16(CRC16Modbus)(data))
+	assert.Equal(t, uint16(0x29b1), CRC16(CRC16CCITTFalse)(data))
+	assert.Equal(t, uint16(0x31c3), CRC16(CRC16XModem)(data))
+	assert.Equal(t, uint16(0x2189), CRC16(CRC16Kermit)(data))
+	assert.Equal(t, uint16(0x906e), CRC16(CRC16X25)(data))
+}
+
+func TestDigests(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("123456789")
+
+	assert.Equal(t, uint32(0xcbf43926), CRC32(crc32.IEEETable)(data))
+	assert.Equal(t, uint32(0x091e01de), Adler32()(data))
+	assert.Equal(t, crc32.ChecksumIEEE(data), Hash32(crc32.NewIEEE)(data))
+	assert.Equal(t, crc64.Checksum(data, crc64.MakeTable(crc64.ECMA)), Hash64(func() hash.Hash64 {
+		return crc64.New(crc64.MakeTable(crc64.ECMA))
+	})(data))
+	assert.Equal(t, uint64(0x06d5573923c6cdfc), Hash64(fnv.New64a)(data))
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	first := func(x []byte, _ uint32) []byte { return x }
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: Checksum(
+				"invalid crc",
+				CRC32(crc32.IEEETable),
+				Count(3, "expected data", Any()),
+				ReadAs[uint32](4, "expected crc", binary.BigEndian),
+				first,
+			),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{},
+					output: nil,
+					err:    common.NewParseError(0, "expected data"),
+				},
+				{
+					input:  []byte{'a', 'b', 'c', 0x35, 0x24, 0x41, 0xc2},
+					output: []byte("abc"),
+				},
+				{
+					input:  []byte{'a', 'b', 'c', 0x35, 0x24, 0x41},
+					output: nil,
+					err:    common.NewParseError(3, "expected crc"),
+				},
+				{
+					input:  []byte{'a', 'b', 'd', 0x35, 0x24, 0x41, 0xc2},
+					output: nil,
+					err:    common.NewParseError(3, "invalid crc: expected 0x352441c2, actual 0xab40d461"),
+				},
+			},
+		},
+		{
+			comb: Checksum(
+				"invalid crc",
+				CRC32(crc32.IEEETable),
+				Or(
+					"expected data",
+					Try(Count(4, "four bytes", Eq("expected 'a'", 'a'))),
+					Count(3, "three bytes", Any()),
+				),
+				ReadAs[uint32](4, "expected crc", binary.BigEndian),
+				first,
+			),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{'a', 'b', 'c', 0x35, 0x24, 0x41, 0xc2},
+					output: []byte("abc"),
+				},
+			},
+		},
+	})
+}
+
+func TestChecksumBefore(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		sum  uint16
+		data string
+	}
+
+	comb := ChecksumBefore(
+		"invalid checksum",
+		CRC16(CRC16XModem),
+		ReadAs[uint16](2, "expected checksum", binary.LittleEndian),
+		Many(0, Any()),
+		func(data []byte, sum uint16) record {
+			return record{sum: sum, data: string(data)}
+		},
+	)
+
+	result, err := Parse([]byte{0xc3, 0x31, '1', '2', '3', '4', '5', '6', '7', '8', '9'}, comb)
+	assert.NoError(t, err)
+	assert.Equal(t, record{sum: 0x31c3, data: "123456789"}, result)
+
+	result, err = Parse([]byte{0xc4, 0x31, '1', '2', '3', '4', '5', '6', '7', '8', '9'}, comb)
+	assert.EqualError(t, err, "Parse error at 0: invalid checksum: expected 0x31c4, actual 0x31c3")
+	assert.Equal(t, record{}, result)
+}
@@ -29,6 +29,20 @@ func Try[T any](c common.Combinator[byte, int, T]) common.Combinator[byte, int,
 	return common.Try[byte, int, T](c)
 }
 
+// LookAhead - parse data by c combinator without consuming input,
+// returns buffer to the previous position in any case.
+func LookAhead[T any](c common.Combinator[byte, int, T]) common.Combinator[byte, int, T] {
+	return common.LookAhead(c)
+}
+
+// NotFollowedBy - succeeds only if c combinator fails, doesn't consume input.
+func NotFollowedBy[T any](
+	errMessage string,
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, bool] {
+	return common.NotFollowedBy(errMessage, c)
+}
+
 // Between - parse sequence of input combinators, skip first and last results.
 func Between[T any, S any, B any](
 	pre common.Combinator[byte, int, T],
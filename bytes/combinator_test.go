@@ -664,3 +664,49 @@ func checkReadAs[T comparable](
 		return assert.EqualValues(t, expected, actual)
 	})
 }
+
+func TestLookAhead(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: Sequence(0, LookAhead(Eq("expected 0x01", 0x01)), Any()),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{0x01, 0x02},
+					output: []byte{0x01, 0x01},
+				},
+				{
+					input:  []byte{0x02},
+					output: nil,
+					err:    common.NewParseError(0, "expected 0x01"),
+				},
+			},
+		},
+	})
+}
+
+func TestNotFollowedBy(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: Sequence(
+				0,
+				Any(),
+				SkipAfter(NotFollowedBy("unexpected 0x00", Eq("E", 0x00)), Any()),
+			),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{0x01, 0x02},
+					output: []byte{0x01, 0x02},
+				},
+				{
+					input:  []byte{0x01, 0x02, 0x00},
+					output: nil,
+					err:    common.NewParseError(2, "unexpected 0x00"),
+				},
+			},
+		},
+	})
+}
@@ -13,3 +13,32 @@ func Trace[T any](
 ) common.Combinator[rune, int, T] {
 	return common.Trace[rune, int, T](l, m, c)
 }
+
+// Named - give a name to c combinator for tracing (see common.EnableTracing).
+// If tracing is disabled it just calls c combinator.
+// Described as rule with name (see common.DescriptionOf).
+func Named[T any](
+	name string,
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, T] {
+	return common.Named(name, c)
+}
+
+// Ref - reference to combinator declared later by pointer, useful for recursive grammars.
+// Described as reference to rule with name (see common.DescriptionOf).
+func Ref[T any](
+	name string,
+	c *common.Combinator[byte, int, T],
+) common.Combinator[byte, int, T] {
+	return common.Ref(name, c)
+}
+
+// Debug - start parsing of data by c combinator under control of d debugger
+// (see common.Debug).
+func Debug[T any](
+	d *common.Debugger,
+	data []byte,
+	c common.Combinator[byte, int, T],
+) {
+	common.Debug(d, c, Buffer(data))
+}
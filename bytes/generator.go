@@ -0,0 +1,22 @@
+package bytes
+
+import (
+	"math/rand/v2"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Generator - make generator of random data parsed by c combinator (see common.Generator).
+// Descriptions must be enabled before making of c combinator (see common.EnableDescriptions).
+// Bytes of classes are taken from all possible bytes.
+func Generator[T any](
+	rnd *rand.Rand,
+	c common.Combinator[byte, int, T],
+) (*common.Generator[byte], error) {
+	alphabet := make([]byte, 256)
+	for i := range alphabet {
+		alphabet[i] = byte(i)
+	}
+
+	return common.NewGenerator(rnd, common.DescriptionOf(c), alphabet)
+}
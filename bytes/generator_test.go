@@ -0,0 +1,49 @@
+package bytes
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	ohsnap "github.com/okneniz/oh-snap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// test is not parallel, because descriptions are enabled globally
+func TestGenerator(t *testing.T) {
+	common.EnableDescriptions()
+	defer common.DisableDescriptions()
+
+	// magic number and bytes with the highest bit set
+	c := Concat(
+		0,
+		SequenceOf("expected magic number", 0xCA, 0xFE),
+		Many(0, Try(Range("expected byte", 0x80, 0xFF))),
+	)
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %v", seed)
+	rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+	g, err := Generator(rnd, c)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ohsnap.Check(t, 1000, g, func(input []byte) bool {
+		if len(input) < 2 || input[0] != 0xCA || input[1] != 0xFE {
+			return false
+		}
+
+		for _, x := range input[2:] {
+			if x < 0x80 {
+				return false
+			}
+		}
+
+		result, err := Parse(input, c)
+		return err == nil && len(result) == len(input)
+	})
+}
@@ -0,0 +1,28 @@
+package bytes
+
+import (
+	"github.com/okneniz/parsec/common"
+	"golang.org/x/exp/constraints"
+)
+
+// Isolate - restrict c combinator to exactly n bytes of the buffer.
+// Combinator c can't read more than n bytes,
+// returns ParseError if it fails or doesn't consume all n bytes.
+func Isolate[T any](
+	errMessage string,
+	n int,
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, T] {
+	return common.Isolate(errMessage, n, c)
+}
+
+// LengthPrefixed - read length of data by length combinator,
+// then parse exactly that count of bytes by body combinator (see Isolate).
+// Useful for TLV-style formats.
+func LengthPrefixed[L constraints.Integer, T any](
+	errMessage string,
+	length common.Combinator[byte, int, L],
+	body common.Combinator[byte, int, T],
+) common.Combinator[byte, int, T] {
+	return common.LengthPrefixed(errMessage, length, body)
+}
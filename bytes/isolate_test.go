@@ -0,0 +1,156 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestIsolate(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: Isolate("expected two bytes", 2, Many(0, Any())),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{},
+					output: nil,
+					err:    common.NewParseError(0, "expected two bytes"),
+				},
+				{
+					input:  []byte{1},
+					output: nil,
+					err:    common.NewParseError(0, "expected two bytes"),
+				},
+				{
+					input:  []byte{1, 2},
+					output: []byte{1, 2},
+				},
+				{
+					input:  []byte{1, 2, 3},
+					output: []byte{1, 2},
+				},
+			},
+		},
+		{
+			comb: Isolate("expected three bytes", 3, Count(2, "two bytes", Any())),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{1, 2, 3},
+					output: nil,
+					err:    common.NewParseError(0, "expected three bytes"),
+				},
+			},
+		},
+		{
+			comb: Isolate("expected one byte", 1, Count(2, "two bytes", Any())),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{1, 2, 3},
+					output: nil,
+					err:    common.NewParseError(0, "expected one byte"),
+				},
+			},
+		},
+		{
+			comb: Isolate(
+				"expected two bytes",
+				2,
+				Or(
+					"expected two or three bytes",
+					Try(Count(3, "three bytes", Any())),
+					Count(2, "two bytes", Any()),
+				),
+			),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{1, 2, 3},
+					output: []byte{1, 2},
+				},
+			},
+		},
+		{
+			comb: Isolate("expected nothing", -1, Many(0, Any())),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{1, 2, 3},
+					output: nil,
+					err:    common.NewParseError(0, "expected nothing"),
+				},
+			},
+		},
+	})
+
+	t.Run("keep position after isolated part", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]byte{1, 2, 3, 4})
+
+		result, err := Isolate("E", 3, Many(0, Any()))(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, result)
+		assert.Equal(t, 3, buf.Position())
+
+		next, err := Any()(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(4), next)
+	})
+
+	t.Run("report unconsumed items", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Parse([]byte{1, 2, 3}, Isolate("E", 3, Any()))
+		assert.Equal(
+			t,
+			common.NewParseError(
+				0,
+				"E",
+				common.NewParseError(1, "expected 3 items to be consumed, actual 1"),
+			),
+			err,
+		)
+	})
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: LengthPrefixed("expected body", Any(), Many(0, Any())),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{},
+					output: nil,
+					err:    common.NewParseError(0, common.ErrEndOfFile.Error()),
+				},
+				{
+					input:  []byte{0},
+					output: []byte{},
+				},
+				{
+					input:  []byte{2, 'a', 'b', 'c'},
+					output: []byte{'a', 'b'},
+				},
+				{
+					input:  []byte{4, 'a', 'b', 'c'},
+					output: nil,
+					err:    common.NewParseError(1, "expected body"),
+				},
+			},
+		},
+	})
+
+	t.Run("parse sequence of records", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Many(0, LengthPrefixed("expected body", ULEB128[uint]("length"), Many(0, Any())))
+
+		result, err := Parse([]byte{1, 'a', 0, 2, 'b', 'c'}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{{'a'}, {}, {'b', 'c'}}, result)
+	})
+}
@@ -0,0 +1,22 @@
+package bytes
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// WithSpan - wrap result of c combinator with positions of buffer
+// before and after parsing.
+func WithSpan[T any](
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, common.Located[T, int]] {
+	return common.WithSpan(c)
+}
+
+// SequenceWithSpan - like Sequence, but wrap results of each combinator
+// with positions, span of all results is returned by Position method.
+func SequenceWithSpan[T any](
+	cap int,
+	cs ...common.Combinator[byte, int, T],
+) common.Combinator[byte, int, common.Locations[T, int]] {
+	return common.SequenceWithSpan(cap, cs...)
+}
@@ -0,0 +1,46 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestWithSpan(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[common.Located[uint16, int]]{
+		{
+			comb: WithSpan(Skip(Any(), Uint16BE("expected uint16"))),
+			cases: []testCase[common.Located[uint16, int]]{
+				{
+					input: []byte{0x00, 0x01, 0x02, 0x03},
+					output: common.Located[uint16, int]{
+						Value: 0x0102,
+						Span:  common.Span[int]{Start: 0, End: 3},
+					},
+				},
+				{
+					input:  []byte{0x00, 0x01},
+					output: common.Located[uint16, int]{},
+					err:    common.NewParseError(1, "expected uint16"),
+				},
+			},
+		},
+	})
+
+	t.Run("sequence", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Parse(
+			[]byte{0xff, 0x00, 0x01, 0x02, 0x00},
+			Skip(Any(), SequenceWithSpan(0, Uint16BE("expected uint16"), Uint16LE("expected uint16"))),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint16{1, 2}, result.Values())
+		assert.Equal(t, common.Span[int]{Start: 3, End: 5}, result[1].Position())
+		assert.Equal(t, common.Span[int]{Start: 1, End: 5}, result.Position())
+	})
+}
@@ -0,0 +1,24 @@
+package bytes
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// Node - wrap c combinator to produce node of syntax tree with name,
+// matched bytes, positions and result of c combinator as value.
+// Nodes produced by Node combinators inside c become children of the node.
+func Node[T any](
+	name string,
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, *common.SyntaxNode[byte, int]] {
+	return common.Node(name, c)
+}
+
+// Lossless - like Node, but produce lossless syntax tree with bytes skipped
+// by Skip, SkipAfter and Padded attached as trivia to token nodes (see common.Lossless).
+func Lossless[T any](
+	name string,
+	c common.Combinator[byte, int, T],
+) common.Combinator[byte, int, *common.SyntaxNode[byte, int]] {
+	return common.Lossless(name, c)
+}
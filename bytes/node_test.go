@@ -0,0 +1,58 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode(t *testing.T) {
+	t.Parallel()
+
+	field := Node("field", LengthPrefixed("expected field", Uint8("expected length"), Many(0, Any())))
+	record := Node("record", Sequence(0, Node("tag", Any()), field, field))
+
+	node, err := Parse([]byte{0x01, 0x02, 0xca, 0xfe, 0x00, 0xff}, record)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, node.Start)
+	assert.Equal(t, 5, node.End)
+	assert.Equal(t, []byte{0x01, 0x02, 0xca, 0xfe, 0x00}, node.Items)
+	assert.Len(t, node.Children, 3)
+	assert.Equal(t, []byte{0xca, 0xfe}, node.Children[1].Value)
+	assert.Equal(t, 1, node.Children[1].Start)
+	assert.Equal(
+		t,
+		`record 0102cafe00 [0 - 5]
+  tag 01 [0 - 1]
+  field 02cafe [1 - 4]
+  field 00 [4 - 5]
+`,
+		node.String(),
+	)
+
+	_, err = Parse([]byte{0x01, 0x02, 0xca}, record)
+	assert.Error(t, err)
+}
+
+func TestLossless(t *testing.T) {
+	t.Parallel()
+
+	padding := Eq("expected padding", 0x00)
+	field := Node("field", LengthPrefixed("expected field", Uint8("expected length"), Many(0, Any())))
+	record := Lossless("record", Many(0, Try(Padded(padding, field))))
+
+	input := []byte{0x00, 0x01, 0xaa, 0x00, 0x00, 0x02, 0xbb, 0xcc, 0x00}
+
+	node, err := Parse(input, record)
+	assert.NoError(t, err)
+	assert.Equal(t, input, node.Source())
+	assert.Len(t, node.Children, 2)
+
+	assert.Equal(t, []byte{0x00}, node.Children[0].Leading)
+	assert.Equal(t, []byte{0x01, 0xaa}, node.Children[0].Items)
+	assert.Equal(t, 1, node.Children[0].Start)
+	assert.Equal(t, []byte{0x00, 0x00}, node.Children[1].Leading)
+	assert.Equal(t, []byte{0x00}, node.Children[1].Trailing)
+	assert.Equal(t, 5, node.Children[1].Start)
+	assert.Equal(t, 8, node.Children[1].End)
+}
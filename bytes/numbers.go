@@ -0,0 +1,189 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Fixed size numbers readers below decode data without reflection
+// and allocations, unlike ReadAs.
+
+// Uint8 - read one byte as uint8.
+func Uint8(errMessage string) common.Combinator[byte, int, uint8] {
+	return fixed(errMessage, 1, func(d [8]byte) uint8 {
+		return d[0]
+	})
+}
+
+// Int8 - read one byte as int8.
+func Int8(errMessage string) common.Combinator[byte, int, int8] {
+	return fixed(errMessage, 1, func(d [8]byte) int8 {
+		return int8(d[0])
+	})
+}
+
+// Uint16BE - read 2 bytes of big endian uint16.
+func Uint16BE(errMessage string) common.Combinator[byte, int, uint16] {
+	return fixed(errMessage, 2, func(d [8]byte) uint16 {
+		return binary.BigEndian.Uint16(d[:])
+	})
+}
+
+// Uint16LE - read 2 bytes of little endian uint16.
+func Uint16LE(errMessage string) common.Combinator[byte, int, uint16] {
+	return fixed(errMessage, 2, func(d [8]byte) uint16 {
+		return binary.LittleEndian.Uint16(d[:])
+	})
+}
+
+// Int16BE - read 2 bytes of big endian int16.
+func Int16BE(errMessage string) common.Combinator[byte, int, int16] {
+	return fixed(errMessage, 2, func(d [8]byte) int16 {
+		return int16(binary.BigEndian.Uint16(d[:]))
+	})
+}
+
+// Int16LE - read 2 bytes of little endian int16.
+func Int16LE(errMessage string) common.Combinator[byte, int, int16] {
+	return fixed(errMessage, 2, func(d [8]byte) int16 {
+		return int16(binary.LittleEndian.Uint16(d[:]))
+	})
+}
+
+// Uint24BE - read 3 bytes of big endian unsigned 24-bit integer, returns it as uint32.
+func Uint24BE(errMessage string) common.Combinator[byte, int, uint32] {
+	return fixed(errMessage, 3, func(d [8]byte) uint32 {
+		return uint32(d[2]) | uint32(d[1])<<8 | uint32(d[0])<<16
+	})
+}
+
+// Uint24LE - read 3 bytes of little endian unsigned 24-bit integer, returns it as uint32.
+func Uint24LE(errMessage string) common.Combinator[byte, int, uint32] {
+	return fixed(errMessage, 3, func(d [8]byte) uint32 {
+		return uint32(d[0]) | uint32(d[1])<<8 | uint32(d[2])<<16
+	})
+}
+
+// Uint32BE - read 4 bytes of big endian uint32.
+func Uint32BE(errMessage string) common.Combinator[byte, int, uint32] {
+	return fixed(errMessage, 4, func(d [8]byte) uint32 {
+		return binary.BigEndian.Uint32(d[:])
+	})
+}
+
+// Uint32LE - read 4 bytes of little endian uint32.
+func Uint32LE(errMessage string) common.Combinator[byte, int, uint32] {
+	return fixed(errMessage, 4, func(d [8]byte) uint32 {
+		return binary.LittleEndian.Uint32(d[:])
+	})
+}
+
+// Int32BE - read 4 bytes of big endian int32.
+func Int32BE(errMessage string) common.Combinator[byte, int, int32] {
+	return fixed(errMessage, 4, func(d [8]byte) int32 {
+		return int32(binary.BigEndian.Uint32(d[:]))
+	})
+}
+
+// Int32LE - read 4 bytes of little endian int32.
+func Int32LE(errMessage string) common.Combinator[byte, int, int32] {
+	return fixed(errMessage, 4, func(d [8]byte) int32 {
+		return int32(binary.LittleEndian.Uint32(d[:]))
+	})
+}
+
+// Uint64BE - read 8 bytes of big endian uint64.
+func Uint64BE(errMessage string) common.Combinator[byte, int, uint64] {
+	return fixed(errMessage, 8, func(d [8]byte) uint64 {
+		return binary.BigEndian.Uint64(d[:])
+	})
+}
+
+// Uint64LE - read 8 bytes of little endian uint64.
+func Uint64LE(errMessage string) common.Combinator[byte, int, uint64] {
+	return fixed(errMessage, 8, func(d [8]byte) uint64 {
+		return binary.LittleEndian.Uint64(d[:])
+	})
+}
+
+// Int64BE - read 8 bytes of big endian int64.
+func Int64BE(errMessage string) common.Combinator[byte, int, int64] {
+	return fixed(errMessage, 8, func(d [8]byte) int64 {
+		return int64(binary.BigEndian.Uint64(d[:]))
+	})
+}
+
+// Int64LE - read 8 bytes of little endian int64.
+func Int64LE(errMessage string) common.Combinator[byte, int, int64] {
+	return fixed(errMessage, 8, func(d [8]byte) int64 {
+		return int64(binary.LittleEndian.Uint64(d[:]))
+	})
+}
+
+// Float32BE - read 4 bytes of big endian IEEE 754 float32.
+func Float32BE(errMessage string) common.Combinator[byte, int, float32] {
+	return fixed(errMessage, 4, func(d [8]byte) float32 {
+		return math.Float32frombits(binary.BigEndian.Uint32(d[:]))
+	})
+}
+
+// Float32LE - read 4 bytes of little endian IEEE 754 float32.
+func Float32LE(errMessage string) common.Combinator[byte, int, float32] {
+	return fixed(errMessage, 4, func(d [8]byte) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(d[:]))
+	})
+}
+
+// Float64BE - read 8 bytes of big endian IEEE 754 float64.
+func Float64BE(errMessage string) common.Combinator[byte, int, float64] {
+	return fixed(errMessage, 8, func(d [8]byte) float64 {
+		return math.Float64frombits(binary.BigEndian.Uint64(d[:]))
+	})
+}
+
+// Float64LE - read 8 bytes of little endian IEEE 754 float64.
+func Float64LE(errMessage string) common.Combinator[byte, int, float64] {
+	return fixed(errMessage, 8, func(d [8]byte) float64 {
+		return math.Float64frombits(binary.LittleEndian.Uint64(d[:]))
+	})
+}
+
+// fixed - read size bytes (no more than 8) and decode them by decode function.
+// Data passed by value to avoid allocations.
+func fixed[T any](
+	errMessage string,
+	size int,
+	decode func([8]byte) T,
+) common.Combinator[byte, int, T] {
+	return func(buf common.Buffer[byte, int]) (T, common.Error[int]) {
+		var data [8]byte
+		var null T
+
+		pos := buf.Position()
+
+		// fast path for bytes buffer
+		if b, ok := buf.(*buffer); ok {
+			if len(b.data)-b.position < size {
+				return null, common.NewParseError(pos, errMessage)
+			}
+
+			copy(data[:], b.data[b.position:b.position+size])
+			b.position += size
+
+			return decode(data), nil
+		}
+
+		for i := 0; i < size; i++ {
+			x, err := buf.Read(true)
+			if err != nil {
+				return null, common.NewParseError(pos, errMessage)
+			}
+
+			data[i] = x
+		}
+
+		return decode(data), nil
+	}
+}
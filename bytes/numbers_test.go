@@ -0,0 +1,175 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	ohsnap "github.com/okneniz/oh-snap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %v", seed)
+	rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+	t.Run("uint8", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint8(), binary.BigEndian, Uint8("E"))
+	})
+
+	t.Run("int8", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int8(), binary.BigEndian, Int8("E"))
+	})
+
+	t.Run("uint16", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint16(), binary.BigEndian, Uint16BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint16(), binary.LittleEndian, Uint16LE("E"))
+	})
+
+	t.Run("int16", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int16(), binary.BigEndian, Int16BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int16(), binary.LittleEndian, Int16LE("E"))
+	})
+
+	t.Run("uint32", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint32(), binary.BigEndian, Uint32BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint32(), binary.LittleEndian, Uint32LE("E"))
+	})
+
+	t.Run("int32", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int32(), binary.BigEndian, Int32BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int32(), binary.LittleEndian, Int32LE("E"))
+	})
+
+	t.Run("uint64", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint64(), binary.BigEndian, Uint64BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Uint64(), binary.LittleEndian, Uint64LE("E"))
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int64(), binary.BigEndian, Int64BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Int64(), binary.LittleEndian, Int64LE("E"))
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Float32(), binary.BigEndian, Float32BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Float32(), binary.LittleEndian, Float32LE("E"))
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Float64(), binary.BigEndian, Float64BE("E"))
+		checkReadAs(t, ohsnap.NewBuilder(rnd).Float64(), binary.LittleEndian, Float64LE("E"))
+	})
+
+	runTests(t, []test[uint32]{
+		{
+			comb: Uint24BE("expected uint24"),
+			cases: []testCase[uint32]{
+				{
+					input:  []byte{0x01, 0x02, 0x03},
+					output: 0x010203,
+				},
+				{
+					input:  []byte{0x01, 0x02},
+					output: 0,
+					err:    common.NewParseError(0, "expected uint24"),
+				},
+			},
+		},
+		{
+			comb: Uint24LE("expected uint24"),
+			cases: []testCase[uint32]{
+				{
+					input:  []byte{0x01, 0x02, 0x03, 0x04},
+					output: 0x030201,
+				},
+				{
+					input:  []byte{},
+					output: 0,
+					err:    common.NewParseError(0, "expected uint24"),
+				},
+			},
+		},
+	})
+
+	t.Run("read from any buffer", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Isolate("E", 3, Uint24BE("expected uint24"))
+
+		result, err := Parse([]byte{0x01, 0x02, 0x03}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0x010203), result)
+
+		_, err = Parse([]byte{0x01, 0x02, 0x03}, Isolate("E", 2, Uint24BE("expected uint24")))
+		assert.Equal(
+			t,
+			common.NewParseError(0, "E", common.NewParseError(0, "expected uint24")),
+			err,
+		)
+	})
+}
+
+func benchmarkNumber[T any](b *testing.B, size int, comb common.Combinator[byte, int, T]) {
+	b.Helper()
+
+	data := make([]byte, size*1024)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	buf := Buffer(data)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if buf.IsEOF() {
+			buf.position = 0
+		}
+
+		if _, err := comb(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNumbers(b *testing.B) {
+	b.Run("ReadAs uint16", func(b *testing.B) {
+		benchmarkNumber(b, 2, ReadAs[uint16](2, "E", binary.BigEndian))
+	})
+
+	b.Run("Uint16BE", func(b *testing.B) {
+		benchmarkNumber(b, 2, Uint16BE("E"))
+	})
+
+	b.Run("ReadAs int32", func(b *testing.B) {
+		benchmarkNumber(b, 4, ReadAs[int32](4, "E", binary.LittleEndian))
+	})
+
+	b.Run("Int32LE", func(b *testing.B) {
+		benchmarkNumber(b, 4, Int32LE("E"))
+	})
+
+	b.Run("ReadAs uint64", func(b *testing.B) {
+		benchmarkNumber(b, 8, ReadAs[uint64](8, "E", binary.BigEndian))
+	})
+
+	b.Run("Uint64BE", func(b *testing.B) {
+		benchmarkNumber(b, 8, Uint64BE("E"))
+	})
+
+	b.Run("ReadAs float64", func(b *testing.B) {
+		benchmarkNumber(b, 8, ReadAs[float64](8, "E", binary.BigEndian))
+	})
+
+	b.Run("Float64BE", func(b *testing.B) {
+		benchmarkNumber(b, 8, Float64BE("E"))
+	})
+}
@@ -0,0 +1,33 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParallel(t *testing.T) {
+	t.Parallel()
+
+	// records - length-prefixed by one byte and separated by zero bytes
+	records := []byte{2, 'a', 'b', 0, 1, 'c', 0, 3, 'd', 'e', 'f'}
+	record := LengthPrefixed("expected record", Any(), Many(0, Try(Any())))
+	separator := Eq("expected separator", 0)
+
+	result, err := ParseParallel(records, 2, separator, record)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("ab"), []byte("c"), []byte("def")}, result)
+
+	broken := []byte{2, 'a', 'b', 0, 3, 'c', 0, 1, 'd'}
+
+	result, err = ParseParallel(broken, 2, separator, record)
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "Parse error at 5: expected record")
+
+	chunks := ParseChunks(broken, 2, separator, record)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, 4, chunks[1].Start)
+	assert.Equal(t, 6, chunks[1].End)
+	assert.EqualError(t, chunks[1].Err, "Parse error at 5: expected record")
+	assert.Equal(t, []byte("d"), chunks[2].Value)
+}
@@ -1,6 +1,9 @@
 package bytes
 
 import (
+	"io"
+	"iter"
+
 	"github.com/okneniz/parsec/common"
 )
 
@@ -20,3 +23,58 @@ func ParseFile[T any](path string, parse common.Combinator[byte, int, T]) (T, er
 
 	return common.Parse[byte, int, T](buf, parse)
 }
+
+// ParseSeq - parse records from reader by c combinator until end of input,
+// yielding each record as soon as it's parsed (see common.ParseSeq).
+// Only unparsed part of input is kept in memory.
+func ParseSeq[T any](
+	r io.Reader,
+	parse common.Combinator[byte, int, T],
+) iter.Seq2[T, common.Error[int]] {
+	return common.ParseSeq(BufferFromReader(r), parse)
+}
+
+// ParseAll - like ParseSeq, but records are separated by sep combinator,
+// for example by newline (see common.ParseAll).
+func ParseAll[T any, B any](
+	r io.Reader,
+	sep common.Combinator[byte, int, B],
+	parse common.Combinator[byte, int, T],
+) iter.Seq2[T, common.Error[int]] {
+	return common.ParseAll(BufferFromReader(r), sep, parse)
+}
+
+// ParseParallel - split bytes to chunks by boundary combinator (for example, newline)
+// and parse chunks by c combinator concurrently in count of workers goroutines
+// (see common.ParseParallel). Returns results in input order
+// or error of the first failed chunk with offset in the whole input.
+func ParseParallel[T any, B any](
+	data []byte,
+	workers int,
+	boundary common.Combinator[byte, int, B],
+	parse common.Combinator[byte, int, T],
+) ([]T, error) {
+	result, err := common.ParseParallel(bufferOf(data), workers, boundary, parse)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseChunks - like ParseParallel, but returns results and errors
+// of all chunks in input order (see common.ParseChunks).
+func ParseChunks[T any, B any](
+	data []byte,
+	workers int,
+	boundary common.Combinator[byte, int, B],
+	parse common.Combinator[byte, int, T],
+) []common.Chunk[int, T] {
+	return common.ParseChunks(bufferOf(data), workers, boundary, parse)
+}
+
+func bufferOf(data []byte) func() common.Buffer[byte, int] {
+	return func() common.Buffer[byte, int] {
+		return Buffer(data)
+	}
+}
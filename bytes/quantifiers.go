@@ -43,3 +43,31 @@ func Count[T any](
 ) common.Combinator[byte, int, []T] {
 	return common.Count[byte, int, T](cap, errMessage, c)
 }
+
+// ManyFold - like Many, but combine bytes which returned by c combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value (see common.ManyFold).
+func ManyFold[T any, A any](
+	init A,
+	c common.Combinator[byte, int, T],
+	f func(A, T) A,
+) common.Combinator[byte, int, A] {
+	return common.ManyFold(init, c, f)
+}
+
+// CountOnly - count bytes which returned by c consumer until it possible,
+// results are discarded. Stop on first error or end of buffer.
+func CountOnly[T any](c common.Combinator[byte, int, T]) common.Combinator[byte, int, int] {
+	return common.CountOnly(c)
+}
+
+// SkipCount - skip exactly n occurrences of bytes parsed by skip combinator
+// before body combinator, results of skip are discarded (see common.SkipCount).
+func SkipCount[T any, S any](
+	n int,
+	errMessage string,
+	skip common.Combinator[byte, int, S],
+	body common.Combinator[byte, int, T],
+) common.Combinator[byte, int, T] {
+	return common.SkipCount(n, errMessage, skip, body)
+}
@@ -172,3 +172,56 @@ func TestCount(t *testing.T) {
 		},
 	})
 }
+
+func TestManyFold(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[int]{
+		{
+			comb: ManyFold(0, Try(Any()), func(acc int, x byte) int { return acc + int(x) }),
+			cases: []testCase[int]{
+				{
+					input:  []byte{},
+					output: 0,
+				},
+				{
+					input:  []byte{1, 2, 3},
+					output: 6,
+				},
+			},
+		},
+		{
+			comb: CountOnly(Try(Eq("expected 0x01", 1))),
+			cases: []testCase[int]{
+				{
+					input:  []byte{1, 1, 2},
+					output: 2,
+				},
+				{
+					input:  []byte{2, 1},
+					output: 0,
+				},
+			},
+		},
+	})
+}
+
+func TestSkipCount(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[byte]{
+		{
+			comb: SkipCount(2, "expected header", Any(), Any()),
+			cases: []testCase[byte]{
+				{
+					input:  []byte{1, 2, 3},
+					output: 3,
+				},
+				{
+					input: []byte{1},
+					err:   common.NewParseError(0, "expected header"),
+				},
+			},
+		},
+	})
+}
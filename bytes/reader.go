@@ -0,0 +1,105 @@
+package bytes
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/okneniz/parsec/common"
+)
+
+type readerBuffer struct {
+	reader *bufio.Reader
+	// data - window of input which is kept in memory
+	data []byte
+	// offset - position of the first item of window
+	offset   int
+	position int
+	eof      bool
+	err      error
+}
+
+var (
+	_ common.Buffer[byte, int] = new(readerBuffer)
+	_ common.Releaser          = new(readerBuffer)
+	_ common.ReadErrorer       = new(readerBuffer)
+)
+
+// Read - read next item, if greedy buffer keep position after reading.
+func (b *readerBuffer) Read(greedy bool) (byte, error) {
+	if b.IsEOF() {
+		return 0, common.ErrEndOfFile
+	}
+
+	x := b.data[b.position-b.offset]
+	if greedy {
+		b.position++
+	}
+
+	return x, nil
+}
+
+// Seek - change buffer position,
+// only positions of window after the last release are allowed.
+func (b *readerBuffer) Seek(x int) error {
+	if b.position == x {
+		return nil
+	}
+
+	if x < b.offset || x >= b.offset+len(b.data) {
+		return common.ErrOutOfBounds
+	}
+
+	b.position = x
+	return nil
+}
+
+// Position - return current buffer position
+func (b *readerBuffer) Position() int {
+	return b.position
+}
+
+// IsEOF - true if reader ended or failed.
+func (b *readerBuffer) IsEOF() bool {
+	for b.position-b.offset >= len(b.data) {
+		if b.eof {
+			return true
+		}
+
+		x, err := b.reader.ReadByte()
+		if err != nil {
+			b.eof = true
+
+			if err != io.EOF {
+				b.err = err
+			}
+
+			continue
+		}
+
+		b.data = append(b.data, x)
+	}
+
+	return false
+}
+
+// Release - drop bytes before current position from memory.
+func (b *readerBuffer) Release() {
+	n := copy(b.data, b.data[b.position-b.offset:])
+	b.data = b.data[:n]
+	b.offset = b.position
+}
+
+// ReadErr - error of reader, nil if reader ended by io.EOF.
+func (b *readerBuffer) ReadErr() error {
+	return b.err
+}
+
+// BufferFromReader - make buffer which reads bytes from reader on demand
+// and use integer for positions. Read bytes are kept in memory until release
+// (see common.Releaser), so it's useful with common.ParseSeq for streams.
+func BufferFromReader(r io.Reader) *readerBuffer {
+	b := new(readerBuffer)
+	b.reader = bufio.NewReader(r)
+	b.data = make([]byte, 0, 64)
+	return b
+}
@@ -0,0 +1,43 @@
+package bytes
+
+import (
+	"regexp"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Regex - compile regular expression (RE2 syntax) to combinator
+// which matches it at the current position.
+// Every byte is matched as a single character with code point
+// equal to its value, so \xff matches byte 0xff and . matches any byte
+// except newline (use (?s) to match it too).
+// Returns the match and capture groups, like regexp.FindSubmatch,
+// groups which did not participate in the match are nil.
+// If it falls, it returns buffer to the previous position.
+// Returns error if pattern can't be compiled.
+func Regex(
+	errMessage string,
+	pattern string,
+) (common.Combinator[byte, int, [][]byte], error) {
+	re, err := regexp.Compile(`\A(?:` + pattern + `)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Regex[byte, int](errMessage, re, func(x byte) rune {
+		return rune(x)
+	}), nil
+}
+
+// MustRegex - like Regex but panics if pattern can't be compiled.
+func MustRegex(
+	errMessage string,
+	pattern string,
+) common.Combinator[byte, int, [][]byte] {
+	c, err := Regex(errMessage, pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
@@ -0,0 +1,64 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestRegex(t *testing.T) {
+	t.Parallel()
+
+	t.Run("binary pattern", func(t *testing.T) {
+		t.Parallel()
+
+		comb := MustRegex("expected jpeg segment", `\xff([\xc0-\xfe])(?s)(..)`)
+
+		result, err := Parse([]byte{0xff, 0xd8, 0x00, 0x80, 0x01}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{{0xff, 0xd8, 0x00, 0x80}, {0xd8}, {0x00, 0x80}}, result)
+
+		_, err = Parse([]byte{0xff, 0x10, 0x00, 0x00}, comb)
+		assert.Equal(t, common.NewParseError(0, "expected jpeg segment"), err)
+	})
+
+	t.Run("optional groups and position", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Sequence(
+			0,
+			MustRegex("expected magic", `GIF(8[79]a)|(PNG)`),
+			MustRegex("expected rest", `(?s).*`),
+		)
+
+		result, err := Parse([]byte("GIF89a\x01\x02"), comb)
+		assert.NoError(t, err)
+		assert.Equal(t, [][][]byte{
+			{[]byte("GIF89a"), []byte("89a"), nil},
+			{{0x01, 0x02}},
+		}, result)
+	})
+
+	t.Run("restore position on failure", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]byte("abcd"))
+
+		_, err := MustRegex("expected abc", `abce`)(buf)
+		assert.Equal(t, common.NewParseError(0, "expected abc"), err)
+		assert.Equal(t, 0, buf.Position())
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Regex("E", `a{2,1}`)
+		assert.Error(t, err)
+
+		assert.Panics(t, func() {
+			MustRegex("E", `*`)
+		})
+	})
+}
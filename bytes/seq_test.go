@@ -0,0 +1,41 @@
+package bytes
+
+import (
+	stdbytes "bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeq(t *testing.T) {
+	t.Parallel()
+
+	// records - length-prefixed by one byte
+	record := LengthPrefixed("expected record", Any(), Many(0, Try(Any())))
+
+	values := make([][]byte, 0)
+	for x, err := range ParseSeq(stdbytes.NewReader([]byte{2, 'a', 'b', 0, 1, 'c'}), record) {
+		assert.NoError(t, err)
+		values = append(values, x)
+	}
+
+	assert.Equal(t, [][]byte{[]byte("ab"), {}, []byte("c")}, values)
+
+	values = values[:0]
+	errs := make([]string, 0)
+
+	separator := Eq("expected separator", '\n')
+	input := stdbytes.NewReader([]byte{2, 'a', 'b', '\n', 3, 'c'})
+
+	for x, err := range ParseAll(input, separator, record) {
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		values = append(values, x)
+	}
+
+	assert.Equal(t, [][]byte{[]byte("ab")}, values)
+	assert.Equal(t, []string{"Parse error at 5: expected record"}, errs)
+}
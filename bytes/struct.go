@@ -0,0 +1,686 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// structTag - name of struct tag used by ReadStruct.
+const structTag = "parsec"
+
+type (
+	// decoder - decode value of field from buffer,
+	// parent is a struct which contains decoding value.
+	decoder func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		parent reflect.Value,
+		value reflect.Value,
+	) *fieldError
+
+	// fieldError - error of field decoding,
+	// path is relative to the struct which is decoding.
+	fieldError struct {
+		position int
+		path     string
+		message  string
+	}
+
+	fieldTag struct {
+		order    binary.ByteOrder
+		size     int
+		lenField string
+		magic    []byte
+		bits     int
+		cond     *condition
+	}
+
+	condition struct {
+		field  string
+		index  int
+		op     string
+		value  int64
+		direct bool
+	}
+
+	bitReader struct {
+		current byte
+		left    int
+	}
+)
+
+// Struct - make combinator which decodes struct T field by field
+// in order of declaration. Decoding is configured by `parsec` struct tags,
+// options are separated by commas:
+//
+//   - be, le - byte order of numbers (big endian by default),
+//     on a nested struct field it applies to all fields of nested struct;
+//   - size=N - fixed count of items of slice or bytes of string;
+//   - len=Field - count of items of slice or bytes of string
+//     taken from previously decoded integer field, not supported for arrays;
+//   - magic=hex - expected constant bytes, for example magic=89504e47;
+//   - bits=N - fixed size integer or bool read from N bits, consecutive bit fields
+//     share bytes (most significant bit first);
+//   - if=Field, if=Field==N, if=Field!=N - decode field only if condition
+//     on previously decoded field is true, otherwise keep zero value;
+//   - "-" - ignore field.
+//
+// Unexported fields are ignored, fields with blank (_) names are read but not stored.
+// Errors contains path of field, for example "IHDR.Header.Width".
+func Struct[T any]() (common.Combinator[byte, int, T], error) {
+	var null T
+
+	t := reflect.TypeOf(null)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct type, actual %v", t)
+	}
+
+	decode, err := compileStruct(t, t.Name(), binary.BigEndian)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		var result T
+
+		value := reflect.ValueOf(&result).Elem()
+
+		if err := decode(buffer, new(bitReader), value, value); err != nil {
+			return null, common.NewParseError(
+				err.position,
+				t.Name()+err.path+": "+err.message,
+			)
+		}
+
+		return result, nil
+	}, nil
+}
+
+// ReadStruct - like Struct, but panics if struct T can't be decoded.
+func ReadStruct[T any]() common.Combinator[byte, int, T] {
+	c, err := Struct[T]()
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func compileStruct(
+	t reflect.Type,
+	path string,
+	order binary.ByteOrder,
+) (decoder, error) {
+	type field struct {
+		index  int
+		name   string
+		blank  bool
+		cond   *condition
+		decode decoder
+	}
+
+	fields := make([]field, 0, t.NumField())
+	indexes := make(map[string]int, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		rawTag := f.Tag.Get(structTag)
+		if rawTag == "-" {
+			continue
+		}
+
+		blank := f.Name == "_"
+		if !f.IsExported() && !blank {
+			continue
+		}
+
+		fieldPath := path + "." + f.Name
+
+		tag, err := parseFieldTag(rawTag, order)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldPath, err)
+		}
+
+		if tag.lenField != "" {
+			if _, exists := indexes[tag.lenField]; !exists {
+				return nil, fmt.Errorf(
+					"%s: length field %s must be declared before",
+					fieldPath,
+					tag.lenField,
+				)
+			}
+		}
+
+		if tag.cond != nil {
+			index, exists := indexes[tag.cond.field]
+			if !exists {
+				return nil, fmt.Errorf(
+					"%s: condition field %s must be declared before",
+					fieldPath,
+					tag.cond.field,
+				)
+			}
+
+			tag.cond.index = index
+		}
+
+		decode, err := compileValue(f.Type, tag, fieldPath, indexes)
+		if err != nil {
+			return nil, err
+		}
+
+		if !blank {
+			indexes[f.Name] = i
+		}
+
+		fields = append(fields, field{
+			index:  i,
+			name:   f.Name,
+			blank:  blank,
+			cond:   tag.cond,
+			decode: decode,
+		})
+	}
+
+	return func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		_ reflect.Value,
+		value reflect.Value,
+	) *fieldError {
+		for _, f := range fields {
+			if f.cond != nil && !f.cond.check(value) {
+				continue
+			}
+
+			target := value.Field(f.index)
+			if f.blank {
+				target = reflect.New(target.Type()).Elem()
+			}
+
+			if err := f.decode(buffer, bits, value, target); err != nil {
+				err.path = "." + f.name + err.path
+				return err
+			}
+		}
+
+		bits.align()
+
+		return nil
+	}, nil
+}
+
+func compileValue(
+	t reflect.Type,
+	tag fieldTag,
+	path string,
+	indexes map[string]int,
+) (decoder, error) {
+	if tag.magic != nil {
+		return compileMagic(t, tag, path)
+	}
+
+	if tag.bits > 0 {
+		return compileBits(t, tag, path)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return compileNumber(t, tag, path)
+	case reflect.Struct:
+		return compileStruct(t, path, tag.order)
+	case reflect.Array:
+		// length of array is fixed, so it can't be read from input
+		if tag.lenField != "" {
+			return nil, fmt.Errorf("%s: len option is not supported for array %v", path, t)
+		}
+
+		return compileSlice(t, tag, path, indexes, t.Len())
+	case reflect.Slice, reflect.String:
+		if tag.size < 0 && tag.lenField == "" {
+			return nil, fmt.Errorf("%s: size or len option is required for %v", path, t)
+		}
+
+		return compileSlice(t, tag, path, indexes, tag.size)
+	default:
+		return nil, fmt.Errorf("%s: unsupported type %v", path, t)
+	}
+}
+
+func compileNumber(
+	t reflect.Type,
+	tag fieldTag,
+	path string,
+) (decoder, error) {
+	size := int(t.Size())
+	if t.Kind() == reflect.Bool {
+		size = 1
+	}
+
+	errMessage := fmt.Sprintf("expected %d bytes of %v", size, t)
+
+	return func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		_ reflect.Value,
+		value reflect.Value,
+	) *fieldError {
+		bits.align()
+
+		pos := buffer.Position()
+
+		data, err := readBytes(buffer, size)
+		if err != nil {
+			return &fieldError{position: pos, message: errMessage}
+		}
+
+		setNumber(value, decodeUint(tag.order, data))
+		return nil
+	}, nil
+}
+
+func compileBits(
+	t reflect.Type,
+	tag fieldTag,
+	path string,
+) (decoder, error) {
+	// size of int and uint depends on platform, so they are not supported like in compileValue
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil, fmt.Errorf("%s: bits option is not supported for %v", path, t)
+	}
+
+	if tag.bits > 64 || (t.Kind() != reflect.Bool && tag.bits > t.Bits()) {
+		return nil, fmt.Errorf("%s: too many bits for %v: %d", path, t, tag.bits)
+	}
+
+	errMessage := fmt.Sprintf("expected %d bits of %v", tag.bits, t)
+	signed := t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64
+
+	return func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		_ reflect.Value,
+		value reflect.Value,
+	) *fieldError {
+		pos := buffer.Position()
+
+		x, err := bits.read(buffer, tag.bits)
+		if err != nil {
+			return &fieldError{position: pos, message: errMessage}
+		}
+
+		if signed && x>>(tag.bits-1)&1 == 1 {
+			x |= ^uint64(0) << tag.bits
+		}
+
+		setNumber(value, x)
+		return nil
+	}, nil
+}
+
+func compileMagic(
+	t reflect.Type,
+	tag fieldTag,
+	path string,
+) (decoder, error) {
+	var set func(reflect.Value, []byte)
+
+	switch {
+	case t.Kind() == reflect.String:
+		set = func(v reflect.Value, data []byte) { v.SetString(string(data)) }
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8:
+		if t.Kind() == reflect.Array && t.Len() != len(tag.magic) {
+			return nil, fmt.Errorf("%s: magic must have %d bytes", path, t.Len())
+		}
+
+		set = func(v reflect.Value, data []byte) { reflect.Copy(v, reflect.ValueOf(data)) }
+		if t.Kind() == reflect.Slice {
+			set = func(v reflect.Value, data []byte) { v.SetBytes(data) }
+		}
+	case t.Kind() >= reflect.Int8 && t.Kind() <= reflect.Int64,
+		t.Kind() >= reflect.Uint8 && t.Kind() <= reflect.Uint64:
+		if int(t.Size()) != len(tag.magic) {
+			return nil, fmt.Errorf("%s: magic must have %d bytes", path, t.Size())
+		}
+
+		set = func(v reflect.Value, data []byte) { setNumber(v, decodeUint(tag.order, data)) }
+	default:
+		return nil, fmt.Errorf("%s: magic option is not supported for %v", path, t)
+	}
+
+	errMessage := fmt.Sprintf("expected magic %x", tag.magic)
+
+	return func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		_ reflect.Value,
+		value reflect.Value,
+	) *fieldError {
+		bits.align()
+
+		pos := buffer.Position()
+
+		data, err := readBytes(buffer, len(tag.magic))
+		if err != nil || string(data) != string(tag.magic) {
+			return &fieldError{position: pos, message: errMessage}
+		}
+
+		set(value, data)
+		return nil
+	}, nil
+}
+
+func compileSlice(
+	t reflect.Type,
+	tag fieldTag,
+	path string,
+	indexes map[string]int,
+	size int,
+) (decoder, error) {
+	lenIndex := -1
+	if tag.lenField != "" {
+		lenIndex = indexes[tag.lenField]
+	}
+
+	count := func(parent reflect.Value) (int, error) {
+		if lenIndex < 0 {
+			return size, nil
+		}
+
+		n, ok := intValue(parent.Field(lenIndex))
+		if !ok {
+			return 0, fmt.Errorf("length field %s is not an integer", tag.lenField)
+		}
+
+		if n < 0 || n > math.MaxInt32 {
+			return 0, fmt.Errorf("invalid length %d", n)
+		}
+
+		return int(n), nil
+	}
+
+	if t.Kind() == reflect.String || t.Elem().Kind() == reflect.Uint8 {
+		return func(
+			buffer common.Buffer[byte, int],
+			bits *bitReader,
+			parent reflect.Value,
+			value reflect.Value,
+		) *fieldError {
+			bits.align()
+
+			pos := buffer.Position()
+
+			n, err := count(parent)
+			if err != nil {
+				return &fieldError{position: pos, message: err.Error()}
+			}
+
+			data, err := readBytes(buffer, n)
+			if err != nil {
+				return &fieldError{position: pos, message: fmt.Sprintf("expected %d bytes", n)}
+			}
+
+			switch t.Kind() {
+			case reflect.String:
+				value.SetString(string(data))
+			case reflect.Array:
+				reflect.Copy(value, reflect.ValueOf(data))
+			default:
+				value.SetBytes(data)
+			}
+
+			return nil
+		}, nil
+	}
+
+	elemTag := tag
+	elemTag.size = -1
+	elemTag.lenField = ""
+	elemTag.cond = nil
+
+	decodeItem, err := compileValue(t.Elem(), elemTag, path+"[]", indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(
+		buffer common.Buffer[byte, int],
+		bits *bitReader,
+		parent reflect.Value,
+		value reflect.Value,
+	) *fieldError {
+		n, err := count(parent)
+		if err != nil {
+			return &fieldError{position: buffer.Position(), message: err.Error()}
+		}
+
+		if t.Kind() == reflect.Slice {
+			// don't trust to length from input for allocation
+			value.Set(reflect.MakeSlice(t, 0, min(n, 1024)))
+		}
+
+		for i := 0; i < n; i++ {
+			item := reflect.New(t.Elem()).Elem()
+			if t.Kind() == reflect.Array {
+				item = value.Index(i)
+			}
+
+			if err := decodeItem(buffer, bits, parent, item); err != nil {
+				err.path = "[" + strconv.Itoa(i) + "]" + err.path
+				return err
+			}
+
+			if t.Kind() == reflect.Slice {
+				value.Set(reflect.Append(value, item))
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func parseFieldTag(raw string, order binary.ByteOrder) (fieldTag, error) {
+	tag := fieldTag{
+		order: order,
+		size:  -1,
+	}
+
+	if raw == "" {
+		return tag, nil
+	}
+
+	for _, option := range strings.Split(raw, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+
+		switch key {
+		case "be":
+			tag.order = binary.BigEndian
+		case "le":
+			tag.order = binary.LittleEndian
+		case "size":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return tag, fmt.Errorf("invalid size: %q", value)
+			}
+
+			tag.size = n
+		case "len":
+			if value == "" {
+				return tag, fmt.Errorf("empty len option")
+			}
+
+			tag.lenField = value
+		case "magic":
+			data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+			if err != nil || len(data) == 0 {
+				return tag, fmt.Errorf("invalid magic: %q", value)
+			}
+
+			tag.magic = data
+		case "bits":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return tag, fmt.Errorf("invalid bits: %q", value)
+			}
+
+			tag.bits = n
+		case "if":
+			cond, err := parseCondition(value)
+			if err != nil {
+				return tag, err
+			}
+
+			tag.cond = cond
+		default:
+			return tag, fmt.Errorf("unknown option: %q", option)
+		}
+	}
+
+	return tag, nil
+}
+
+func parseCondition(raw string) (*condition, error) {
+	for _, op := range []string{"==", "!="} {
+		field, value, found := strings.Cut(raw, op)
+		if !found {
+			continue
+		}
+
+		n, err := strconv.ParseInt(value, 0, 64)
+		if err != nil || field == "" {
+			return nil, fmt.Errorf("invalid condition: %q", raw)
+		}
+
+		return &condition{field: field, op: op, value: n}, nil
+	}
+
+	if raw == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+
+	return &condition{field: raw, direct: true}, nil
+}
+
+func (c *condition) check(parent reflect.Value) bool {
+	field := parent.Field(c.index)
+
+	var x int64
+
+	if field.Kind() == reflect.Bool {
+		if field.Bool() {
+			x = 1
+		}
+	} else if n, ok := intValue(field); ok {
+		x = n
+	}
+
+	switch {
+	case c.direct:
+		return x != 0
+	case c.op == "==":
+		return x == c.value
+	default:
+		return x != c.value
+	}
+}
+
+// read - read n bits from buffer, most significant bit first.
+func (r *bitReader) read(buffer common.Buffer[byte, int], n int) (uint64, error) {
+	var x uint64
+
+	for n > 0 {
+		if r.left == 0 {
+			b, err := buffer.Read(true)
+			if err != nil {
+				return 0, err
+			}
+
+			r.current = b
+			r.left = 8
+		}
+
+		take := min(n, r.left)
+		chunk := (r.current >> (r.left - take)) & (1<<take - 1)
+
+		x = x<<take | uint64(chunk)
+		r.left -= take
+		n -= take
+	}
+
+	return x, nil
+}
+
+// align - skip rest bits of current byte.
+func (r *bitReader) align() {
+	r.left = 0
+}
+
+func readBytes(buffer common.Buffer[byte, int], n int) ([]byte, error) {
+	// don't trust to length from input for allocation
+	data := make([]byte, 0, min(n, 4096))
+
+	for i := 0; i < n; i++ {
+		b, err := buffer.Read(true)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, b)
+	}
+
+	return data, nil
+}
+
+func decodeUint(order binary.ByteOrder, data []byte) uint64 {
+	switch len(data) {
+	case 1:
+		return uint64(data[0])
+	case 2:
+		return uint64(order.Uint16(data))
+	case 4:
+		return uint64(order.Uint32(data))
+	default:
+		return order.Uint64(data)
+	}
+}
+
+func setNumber(value reflect.Value, x uint64) {
+	switch value.Kind() {
+	case reflect.Bool:
+		value.SetBool(x != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(x))
+	case reflect.Float32:
+		value.SetFloat(float64(math.Float32frombits(uint32(x))))
+	case reflect.Float64:
+		value.SetFloat(math.Float64frombits(x))
+	default:
+		value.SetUint(x)
+	}
+}
+
+func intValue(value reflect.Value) (int64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint()), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,287 @@
+package bytes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+type (
+	testStructHeader struct {
+		Magic   [2]byte `parsec:"magic=cafe"`
+		_       [1]byte
+		Width   uint32
+		Height  uint16 `parsec:"le"`
+		Ratio   float32
+		Visible bool
+		Offset  int16
+		ignored uint8
+	}
+
+	testStructBits struct {
+		Version  uint8 `parsec:"bits=3"`
+		Kind     uint8 `parsec:"bits=5"`
+		Delta    int8  `parsec:"bits=4"`
+		Enabled  bool  `parsec:"bits=1"`
+		Reserved uint8 `parsec:"bits=3"`
+		Length   uint16
+	}
+
+	testStructLength struct {
+		Count uint8
+		Items []uint16 `parsec:"len=Count"`
+		Name  string   `parsec:"size=3"`
+		Data  []byte   `parsec:"len=Count"`
+	}
+
+	testStructCondition struct {
+		Kind    uint8
+		Extra   uint16 `parsec:"if=Kind==2"`
+		HasTail bool
+		Tail    uint8 `parsec:"if=HasTail"`
+		Other   uint8 `parsec:"if=Kind!=2"`
+	}
+
+	testStructEntry struct {
+		Key   uint8
+		Value uint16
+	}
+
+	testStructNested struct {
+		Header  testStructEntry `parsec:"le"`
+		Count   uint8
+		Entries []testStructEntry `parsec:"len=Count"`
+		Skipped uint8             `parsec:"-"`
+	}
+)
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("numbers and magic", func(t *testing.T) {
+		t.Parallel()
+
+		comb := ReadStruct[testStructHeader]()
+
+		input := []byte{
+			0xca, 0xfe,
+			0x00,
+			0x00, 0x00, 0x01, 0x02,
+			0x02, 0x01,
+			0x3f, 0xc0, 0x00, 0x00,
+			0x01,
+			0xff, 0xfe,
+		}
+
+		result, err := Parse(input, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructHeader{
+			Magic:   [2]byte{0xca, 0xfe},
+			Width:   0x0102,
+			Height:  0x0102,
+			Ratio:   1.5,
+			Visible: true,
+			Offset:  -2,
+		}, result)
+
+		_, err = Parse([]byte{0xca, 0xfa, 0x00}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(0, "testStructHeader.Magic: expected magic cafe"),
+			err,
+		)
+
+		_, err = Parse(input[:5], comb)
+		assert.Equal(
+			t,
+			common.NewParseError(3, "testStructHeader.Width: expected 4 bytes of uint32"),
+			err,
+		)
+	})
+
+	t.Run("bit fields", func(t *testing.T) {
+		t.Parallel()
+
+		comb := ReadStruct[testStructBits]()
+
+		result, err := Parse([]byte{0b010_10011, 0b1110_1_101, 0x01, 0x00}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructBits{
+			Version:  2,
+			Kind:     19,
+			Delta:    -2,
+			Enabled:  true,
+			Reserved: 5,
+			Length:   256,
+		}, result)
+
+		_, err = Parse([]byte{0b010_10011}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(1, "testStructBits.Delta: expected 4 bits of int8"),
+			err,
+		)
+	})
+
+	t.Run("length from field", func(t *testing.T) {
+		t.Parallel()
+
+		comb := ReadStruct[testStructLength]()
+
+		result, err := Parse([]byte{2, 0, 1, 0, 2, 'a', 'b', 'c', 9, 8}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructLength{
+			Count: 2,
+			Items: []uint16{1, 2},
+			Name:  "abc",
+			Data:  []byte{9, 8},
+		}, result)
+
+		result, err = Parse([]byte{0, 'a', 'b', 'c'}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructLength{
+			Items: []uint16{},
+			Name:  "abc",
+			Data:  []byte{},
+		}, result)
+
+		_, err = Parse([]byte{255, 0, 1}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(3, "testStructLength.Items[1]: expected 2 bytes of uint16"),
+			err,
+		)
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		t.Parallel()
+
+		comb := ReadStruct[testStructCondition]()
+
+		result, err := Parse([]byte{2, 0x01, 0x02, 1, 7}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructCondition{
+			Kind:    2,
+			Extra:   0x0102,
+			HasTail: true,
+			Tail:    7,
+		}, result)
+
+		result, err = Parse([]byte{1, 0, 3}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructCondition{
+			Kind:  1,
+			Other: 3,
+		}, result)
+	})
+
+	t.Run("nested structs", func(t *testing.T) {
+		t.Parallel()
+
+		comb := ReadStruct[testStructNested]()
+
+		result, err := Parse([]byte{1, 0x02, 0x00, 2, 3, 0x00, 0x04, 5, 0x00, 0x06}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, testStructNested{
+			Header: testStructEntry{Key: 1, Value: 2},
+			Count:  2,
+			Entries: []testStructEntry{
+				{Key: 3, Value: 4},
+				{Key: 5, Value: 6},
+			},
+		}, result)
+
+		_, err = Parse([]byte{1, 0x02, 0x00, 2, 3, 0x00, 0x04, 5, 0x00}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(8, "testStructNested.Entries[1].Value: expected 2 bytes of uint16"),
+			err,
+		)
+	})
+
+	t.Run("composes with other combinators", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Many(0, ReadStruct[testStructEntry]())
+
+		result, err := Parse([]byte{1, 0, 2, 3, 0, 4}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, []testStructEntry{{1, 2}, {3, 4}}, result)
+	})
+
+	t.Run("invalid structs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Struct[int]()
+		assert.EqualError(t, err, "expected struct type, actual int")
+
+		_, err = Struct[struct {
+			Data []byte
+		}]()
+		assert.EqualError(t, err, ".Data: size or len option is required for []uint8")
+
+		_, err = Struct[struct {
+			Data  []byte `parsec:"len=Count"`
+			Count uint8
+		}]()
+		assert.EqualError(t, err, ".Data: length field Count must be declared before")
+
+		_, err = Struct[struct {
+			Count uint8
+			Items [2]uint16 `parsec:"len=Count"`
+		}]()
+		assert.EqualError(t, err, ".Items: len option is not supported for array [2]uint16")
+
+		_, err = Struct[struct {
+			Count uint8
+			Data  [4]byte `parsec:"len=Count"`
+		}]()
+		assert.EqualError(t, err, ".Data: len option is not supported for array [4]uint8")
+
+		_, err = Struct[struct {
+			Value uint8 `parsec:"big"`
+		}]()
+		assert.EqualError(t, err, `.Value: unknown option: "big"`)
+
+		_, err = Struct[struct {
+			Value int
+		}]()
+		assert.EqualError(t, err, ".Value: unsupported type int")
+
+		_, err = Struct[struct {
+			Value uint8 `parsec:"bits=9"`
+		}]()
+		assert.EqualError(t, err, ".Value: too many bits for uint8: 9")
+
+		_, err = Struct[struct {
+			Value int `parsec:"bits=4"`
+		}]()
+		assert.EqualError(t, err, ".Value: bits option is not supported for int")
+
+		_, err = Struct[struct {
+			Value uint `parsec:"bits=4"`
+		}]()
+		assert.EqualError(t, err, ".Value: bits option is not supported for uint")
+
+		assert.Panics(t, func() {
+			ReadStruct[struct {
+				Value float64 `parsec:"magic=00"`
+			}]()
+		})
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		t.Parallel()
+
+		type floats struct {
+			X float64 `parsec:"le"`
+		}
+
+		result, err := Parse([]byte{0, 0, 0, 0, 0, 0, 0xf0, 0x7f}, ReadStruct[floats]())
+		assert.NoError(t, err)
+		assert.True(t, math.IsInf(result.X, 1))
+	})
+}
@@ -0,0 +1,214 @@
+package bytes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Charset - decode bytes of text to Go string.
+type Charset func(data []byte) (string, error)
+
+var (
+	// CharsetASCII - 7-bit ASCII, fails on bytes greater than 0x7f.
+	CharsetASCII Charset = decodeASCII
+	// CharsetLatin1 - ISO 8859-1, every byte is a code point.
+	CharsetLatin1 Charset = decodeLatin1
+	// CharsetUTF8 - UTF-8, fails on invalid sequences.
+	CharsetUTF8 Charset = decodeUTF8
+	// CharsetUTF16LE - little endian UTF-16 without byte order mark.
+	CharsetUTF16LE Charset = decodeUTF16LE
+	// CharsetUTF16BE - big endian UTF-16 without byte order mark.
+	CharsetUTF16BE Charset = decodeUTF16BE
+	// CharsetUTF16 - UTF-16 with byte order detection by BOM,
+	// big endian if there is no BOM. BOM is not included in result.
+	CharsetUTF16 Charset = decodeUTF16
+)
+
+// NullTerminated - read string of at most maxLen bytes followed by NUL (0x00) byte,
+// NUL is consumed but not included in result. Fails if NUL is not found
+// after the first maxLen bytes.
+func NullTerminated(errMessage string, maxLen int) common.Combinator[byte, int, []byte] {
+	return func(buffer common.Buffer[byte, int]) ([]byte, common.Error[int]) {
+		pos := buffer.Position()
+
+		result := make([]byte, 0)
+
+		for i := 0; i <= maxLen; i++ {
+			b, err := buffer.Read(true)
+			if err != nil {
+				return nil, common.NewParseError(pos, errMessage)
+			}
+
+			if b == 0x00 {
+				return result, nil
+			}
+
+			result = append(result, b)
+		}
+
+		return nil, common.NewParseError(pos, errMessage)
+	}
+}
+
+// CString - read NUL-terminated string (see NullTerminated).
+func CString(errMessage string, maxLen int) common.Combinator[byte, int, string] {
+	return Cast(NullTerminated(errMessage, maxLen), func(data []byte) (string, error) {
+		return string(data), nil
+	})
+}
+
+// FixedField - read exactly size bytes and trim trailing padding bytes,
+// for example NUL or space.
+func FixedField(errMessage string, size int, padding ...byte) common.Combinator[byte, int, []byte] {
+	pad := string(padding)
+
+	return func(buffer common.Buffer[byte, int]) ([]byte, common.Error[int]) {
+		pos := buffer.Position()
+
+		data, err := readBytes(buffer, size)
+		if err != nil {
+			return nil, common.NewParseError(pos, errMessage)
+		}
+
+		end := len(data)
+		for end > 0 && strings.IndexByte(pad, data[end-1]) >= 0 {
+			end--
+		}
+
+		return data[:end], nil
+	}
+}
+
+// FixedCString - read exactly size bytes and return bytes before the first NUL,
+// like fields of tar header.
+func FixedCString(errMessage string, size int) common.Combinator[byte, int, []byte] {
+	return func(buffer common.Buffer[byte, int]) ([]byte, common.Error[int]) {
+		pos := buffer.Position()
+
+		data, err := readBytes(buffer, size)
+		if err != nil {
+			return nil, common.NewParseError(pos, errMessage)
+		}
+
+		for i, b := range data {
+			if b == 0x00 {
+				return data[:i], nil
+			}
+		}
+
+		return data, nil
+	}
+}
+
+// Text - decode bytes returned by c combinator to string by charset.
+// Returns ParseError at the starting position if data can't be decoded.
+func Text(
+	errMessage string,
+	charset Charset,
+	c common.Combinator[byte, int, []byte],
+) common.Combinator[byte, int, string] {
+	return func(buffer common.Buffer[byte, int]) (string, common.Error[int]) {
+		pos := buffer.Position()
+
+		data, err := c(buffer)
+		if err != nil {
+			return "", err
+		}
+
+		text, decodeErr := charset(data)
+		if decodeErr != nil {
+			return "", common.NewParseError(
+				pos,
+				errMessage,
+				common.NewParseError(pos, decodeErr.Error()),
+			)
+		}
+
+		return text, nil
+	}
+}
+
+func decodeASCII(data []byte) (string, error) {
+	for i, b := range data {
+		if b > 0x7f {
+			return "", fmt.Errorf("invalid ASCII byte 0x%02x at %d", b, i)
+		}
+	}
+
+	return string(data), nil
+}
+
+func decodeLatin1(data []byte) (string, error) {
+	b := new(strings.Builder)
+	b.Grow(len(data))
+
+	for _, x := range data {
+		b.WriteRune(rune(x))
+	}
+
+	return b.String(), nil
+}
+
+func decodeUTF8(data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", errors.New("invalid UTF-8 text")
+	}
+
+	return string(data), nil
+}
+
+func decodeUTF16LE(data []byte) (string, error) {
+	return decodeUTF16Units(data, func(b []byte) uint16 {
+		return uint16(b[0]) | uint16(b[1])<<8
+	})
+}
+
+func decodeUTF16BE(data []byte) (string, error) {
+	return decodeUTF16Units(data, func(b []byte) uint16 {
+		return uint16(b[1]) | uint16(b[0])<<8
+	})
+}
+
+func decodeUTF16(data []byte) (string, error) {
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xff && data[1] == 0xfe:
+			return decodeUTF16LE(data[2:])
+		case data[0] == 0xfe && data[1] == 0xff:
+			return decodeUTF16BE(data[2:])
+		}
+	}
+
+	return decodeUTF16BE(data)
+}
+
+func decodeUTF16Units(data []byte, unit func([]byte) uint16) (string, error) {
+	if len(data)%2 != 0 {
+		return "", errors.New("odd length of UTF-16 text")
+	}
+
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		units = append(units, unit(data[i:i+2]))
+	}
+
+	for i := 0; i < len(units); i++ {
+		switch {
+		case utf16.IsSurrogate(rune(units[i])) && units[i] >= 0xdc00:
+			return "", fmt.Errorf("unexpected low surrogate at %d", i*2)
+		case utf16.IsSurrogate(rune(units[i])):
+			if i+1 >= len(units) || units[i+1] < 0xdc00 || units[i+1] > 0xdfff {
+				return "", fmt.Errorf("unpaired high surrogate at %d", i*2)
+			}
+
+			i++
+		}
+	}
+
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,217 @@
+package bytes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestNullTerminated(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: NullTerminated("expected c string", 3),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte{},
+					output: nil,
+					err:    common.NewParseError(0, "expected c string"),
+				},
+				{
+					input:  []byte{0x00},
+					output: []byte{},
+				},
+				{
+					input:  []byte{'a', 'b', 'c', 0x00, 'd'},
+					output: []byte("abc"),
+				},
+				{
+					input:  []byte{'a', 'b', 'c', 'd', 0x00},
+					output: nil,
+					err:    common.NewParseError(0, "expected c string"),
+				},
+				{
+					input:  []byte{'a', 'b', 'c'},
+					output: nil,
+					err:    common.NewParseError(0, "expected c string"),
+				},
+				{
+					input:  []byte{'a', 'b'},
+					output: nil,
+					err:    common.NewParseError(0, "expected c string"),
+				},
+			},
+		},
+	})
+
+	t.Run("continue after terminator", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Sequence(0, CString("expected name", 16), CString("expected value", 16))
+
+		result, err := Parse([]byte("key\x00value\x00"), comb)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"key", "value"}, result)
+	})
+}
+
+func TestFixedField(t *testing.T) {
+	t.Parallel()
+
+	runTestsSlice(t, []test[[]byte]{
+		{
+			comb: FixedField("expected 6 bytes", 6, 0x00, ' '),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte("ab"),
+					output: nil,
+					err:    common.NewParseError(0, "expected 6 bytes"),
+				},
+				{
+					input:  []byte("ab c \x00"),
+					output: []byte("ab c"),
+				},
+				{
+					input:  []byte("abcdefg"),
+					output: []byte("abcdef"),
+				},
+				{
+					input:  []byte("      "),
+					output: []byte{},
+				},
+			},
+		},
+		{
+			comb: FixedCString("expected 6 bytes", 6),
+			cases: []testCase[[]byte]{
+				{
+					input:  []byte("ab\x00cd\x00"),
+					output: []byte("ab"),
+				},
+				{
+					input:  []byte("abcdef"),
+					output: []byte("abcdef"),
+				},
+			},
+		},
+	})
+
+	t.Run("consume whole field", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Sequence(0, FixedCString("E", 4), FixedField("E", 2, ' '))
+
+		result, err := Parse([]byte("a\x00b\x00c "), comb)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("a"), []byte("c")}, result)
+	})
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	all := Many(0, Any())
+
+	runTests(t, []test[string]{
+		{
+			comb: Text("expected ascii", CharsetASCII, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte("hello"),
+					output: "hello",
+				},
+				{
+					input:  []byte{'h', 0xe9},
+					output: "",
+					err:    common.NewParseError(0, "expected ascii"),
+				},
+			},
+		},
+		{
+			comb: Text("expected latin-1", CharsetLatin1, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte{'c', 'a', 'f', 0xe9},
+					output: "café",
+				},
+			},
+		},
+		{
+			comb: Text("expected utf-8", CharsetUTF8, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte("café"),
+					output: "café",
+				},
+				{
+					input:  []byte{'c', 0xe9},
+					output: "",
+					err:    common.NewParseError(0, "expected utf-8"),
+				},
+			},
+		},
+		{
+			comb: Text("expected utf-16le", CharsetUTF16LE, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte{'h', 0x00, 'i', 0x00, 0x34, 0xd8, 0x1e, 0xdd},
+					output: "hi𝄞",
+				},
+				{
+					input:  []byte{'h', 0x00, 'i'},
+					output: "",
+					err:    common.NewParseError(0, "expected utf-16le"),
+				},
+				{
+					input:  []byte{0x34, 0xd8, 'i', 0x00},
+					output: "",
+					err:    common.NewParseError(0, "expected utf-16le"),
+				},
+			},
+		},
+		{
+			comb: Text("expected utf-16be", CharsetUTF16BE, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte{0x00, 'h', 0x00, 'i', 0xd8, 0x34, 0xdd, 0x1e},
+					output: "hi𝄞",
+				},
+				{
+					input:  []byte{0xdd, 0x1e},
+					output: "",
+					err:    common.NewParseError(0, "expected utf-16be"),
+				},
+			},
+		},
+		{
+			comb: Text("expected utf-16", CharsetUTF16, all),
+			cases: []testCase[string]{
+				{
+					input:  []byte{0xff, 0xfe, 'h', 0x00, 'i', 0x00},
+					output: "hi",
+				},
+				{
+					input:  []byte{0xfe, 0xff, 0x00, 'h', 0x00, 'i'},
+					output: "hi",
+				},
+				{
+					input:  []byte{0x00, 'h', 0x00, 'i'},
+					output: "hi",
+				},
+			},
+		},
+	})
+
+	t.Run("decode latin-1 text of fixed field", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Text("expected name", CharsetLatin1, FixedField("expected name", 8, 0x00))
+
+		result, err := Parse([]byte{'M', 0xfc, 'l', 'l', 'e', 'r', 0x00, 0x00}, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, "Müller", result)
+	})
+}
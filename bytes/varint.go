@@ -0,0 +1,312 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/okneniz/parsec/common"
+	"golang.org/x/exp/constraints"
+)
+
+var errInvalidUTF8 = errors.New("invalid UTF-8 sequence")
+
+// ULEB128 - read unsigned LEB128 encoded integer.
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func ULEB128[T constraints.Unsigned](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		x, err := readUvarint(buffer)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		return castUnsigned[T](pos, errMessage, x)
+	}
+}
+
+// SLEB128 - read signed LEB128 encoded integer.
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func SLEB128[T constraints.Signed](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		x, err := readSLEB128(buffer)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		return castSigned[T](pos, errMessage, x)
+	}
+}
+
+// Uvarint - read protobuf unsigned varint (the same encoding as ULEB128).
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func Uvarint[T constraints.Unsigned](errMessage string) common.Combinator[byte, int, T] {
+	return ULEB128[T](errMessage)
+}
+
+// Varint - read protobuf zig-zag encoded signed varint (sint32, sint64).
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func Varint[T constraints.Signed](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		u, err := readUvarint(buffer)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		x := int64(u >> 1)
+		if u&1 != 0 {
+			x = ^x
+		}
+
+		return castSigned[T](pos, errMessage, x)
+	}
+}
+
+// QUICVarint - read QUIC variable-length integer (RFC 9000, section 16).
+// Two most significant bits of the first byte define length of integer.
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func QUICVarint[T constraints.Unsigned](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		b, err := buffer.Read(true)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		length := 1 << (b >> 6)
+		x := uint64(b & 0x3f)
+
+		for i := 1; i < length; i++ {
+			b, err = buffer.Read(true)
+			if err != nil {
+				return 0, varintError(pos, errMessage, err)
+			}
+
+			x = x<<8 | uint64(b)
+		}
+
+		return castUnsigned[T](pos, errMessage, x)
+	}
+}
+
+// CompactSize - read Bitcoin compact size unsigned integer.
+// Values below 0xfd are stored in one byte, prefixes 0xfd, 0xfe and 0xff
+// are followed by little endian uint16, uint32 and uint64 accordingly.
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func CompactSize[T constraints.Unsigned](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		prefix, err := buffer.Read(true)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		var size int
+
+		switch prefix {
+		case 0xfd:
+			size = 2
+		case 0xfe:
+			size = 4
+		case 0xff:
+			size = 8
+		default:
+			return castUnsigned[T](pos, errMessage, uint64(prefix))
+		}
+
+		var x uint64
+
+		for i := 0; i < size; i++ {
+			b, err := buffer.Read(true)
+			if err != nil {
+				return 0, varintError(pos, errMessage, err)
+			}
+
+			x |= uint64(b) << (8 * i)
+		}
+
+		return castUnsigned[T](pos, errMessage, x)
+	}
+}
+
+// GitVarint - read Git pack file offset encoded integer (used by ofs-delta objects).
+// Unlike LEB128 it is big endian and adds one to the value on each continuation byte,
+// so every value has only one encoding.
+// Returns ParseError at the starting offset if input is truncated
+// or the value overflows T.
+func GitVarint[T constraints.Unsigned](errMessage string) common.Combinator[byte, int, T] {
+	return func(buffer common.Buffer[byte, int]) (T, common.Error[int]) {
+		pos := buffer.Position()
+
+		b, err := buffer.Read(true)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		x := uint64(b & 0x7f)
+
+		for b&0x80 != 0 {
+			x++
+
+			if x == 0 || x>>(64-7) != 0 {
+				return 0, varintError(pos, errMessage, common.ErrOverflow)
+			}
+
+			b, err = buffer.Read(true)
+			if err != nil {
+				return 0, varintError(pos, errMessage, err)
+			}
+
+			x = x<<7 | uint64(b&0x7f)
+		}
+
+		return castUnsigned[T](pos, errMessage, x)
+	}
+}
+
+// UTF8 - read one UTF-8 encoded character.
+// Returns ParseError at the starting offset if input is truncated
+// or the sequence is not valid UTF-8.
+func UTF8(errMessage string) common.Combinator[byte, int, rune] {
+	return func(buffer common.Buffer[byte, int]) (rune, common.Error[int]) {
+		pos := buffer.Position()
+
+		var data [utf8.UTFMax]byte
+
+		b, err := buffer.Read(true)
+		if err != nil {
+			return 0, varintError(pos, errMessage, err)
+		}
+
+		data[0] = b
+
+		var size int
+
+		switch {
+		case b < 0x80:
+			return rune(b), nil
+		case b&0xe0 == 0xc0:
+			size = 2
+		case b&0xf0 == 0xe0:
+			size = 3
+		case b&0xf8 == 0xf0:
+			size = 4
+		default:
+			return 0, varintError(pos, errMessage, errInvalidUTF8)
+		}
+
+		for i := 1; i < size; i++ {
+			b, err = buffer.Read(true)
+			if err != nil {
+				return 0, varintError(pos, errMessage, err)
+			}
+
+			data[i] = b
+		}
+
+		r, n := utf8.DecodeRune(data[:size])
+		if n != size {
+			return 0, varintError(pos, errMessage, errInvalidUTF8)
+		}
+
+		return r, nil
+	}
+}
+
+func readUvarint(buffer common.Buffer[byte, int]) (uint64, error) {
+	var x uint64
+	var s uint
+
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := buffer.Read(true)
+		if err != nil {
+			return 0, err
+		}
+
+		if b < 0x80 {
+			if i == binary.MaxVarintLen64-1 && b > 1 {
+				return 0, common.ErrOverflow
+			}
+
+			return x | uint64(b)<<s, nil
+		}
+
+		x |= uint64(b&0x7f) << s
+		s += 7
+	}
+
+	return 0, common.ErrOverflow
+}
+
+func readSLEB128(buffer common.Buffer[byte, int]) (int64, error) {
+	var x int64
+	var s uint
+
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := buffer.Read(true)
+		if err != nil {
+			return 0, err
+		}
+
+		// last byte may contain only the sign bit
+		if i == binary.MaxVarintLen64-1 && b != 0x00 && b != 0x7f {
+			return 0, common.ErrOverflow
+		}
+
+		x |= int64(b&0x7f) << s
+		s += 7
+
+		if b&0x80 == 0 {
+			if s < 64 && b&0x40 != 0 {
+				x |= -1 << s
+			}
+
+			return x, nil
+		}
+	}
+
+	return 0, common.ErrOverflow
+}
+
+func castUnsigned[T constraints.Unsigned](
+	pos int,
+	errMessage string,
+	x uint64,
+) (T, common.Error[int]) {
+	result := T(x)
+	if uint64(result) != x {
+		return 0, varintError(pos, errMessage, common.ErrOverflow)
+	}
+
+	return result, nil
+}
+
+func castSigned[T constraints.Signed](
+	pos int,
+	errMessage string,
+	x int64,
+) (T, common.Error[int]) {
+	result := T(x)
+	if int64(result) != x {
+		return 0, varintError(pos, errMessage, common.ErrOverflow)
+	}
+
+	return result, nil
+}
+
+func varintError(pos int, errMessage string, err error) common.Error[int] {
+	return common.NewParseError(pos, errMessage, common.NewParseError(pos, err.Error()))
+}
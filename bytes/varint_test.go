@@ -0,0 +1,433 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	ohsnap "github.com/okneniz/oh-snap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestULEB128(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[uint64]{
+		{
+			comb: ULEB128[uint64]("expected uleb128"),
+			cases: []testCase[uint64]{
+				{
+					input:  []byte{},
+					output: 0,
+					err:    common.NewParseError(0, "expected uleb128"),
+				},
+				{
+					input:  []byte{0x02},
+					output: 2,
+				},
+				{
+					input:  []byte{0xe5, 0x8e, 0x26},
+					output: 624485,
+				},
+				{
+					input:  []byte{0xe5, 0x8e},
+					output: 0,
+					err:    common.NewParseError(0, "expected uleb128"),
+				},
+				{
+					input:  []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+					output: 1<<64 - 1,
+				},
+				{
+					input:  []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02},
+					output: 0,
+					err:    common.NewParseError(0, "expected uleb128"),
+				},
+			},
+		},
+	})
+
+	runTests(t, []test[uint8]{
+		{
+			comb: ULEB128[uint8]("expected uleb128"),
+			cases: []testCase[uint8]{
+				{
+					input:  []byte{0xff, 0x01},
+					output: 255,
+				},
+				{
+					input:  []byte{0x80, 0x02},
+					output: 0,
+					err:    common.NewParseError(0, "expected uleb128"),
+				},
+			},
+		},
+	})
+
+	t.Run("overflow and truncation are reported at the starting offset", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Skip(Any(), ULEB128[uint8]("expected uleb128"))
+
+		_, err := Parse([]byte{0x00, 0x80, 0x02}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(1, "expected uleb128", common.NewParseError(1, common.ErrOverflow.Error())),
+			err,
+		)
+
+		_, err = Parse([]byte{0x00, 0x80}, comb)
+		assert.Equal(
+			t,
+			common.NewParseError(1, "expected uleb128", common.NewParseError(1, common.ErrEndOfFile.Error())),
+			err,
+		)
+	})
+
+	t.Run("decode values encoded by encoding/binary", func(t *testing.T) {
+		t.Parallel()
+
+		seed := time.Now().UnixNano()
+		t.Logf("seed: %v", seed)
+		rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+		comb := Uvarint[uint64]("E")
+
+		ohsnap.Check(t, 10_000, ohsnap.NewBuilder(rnd).Uint64(), func(x uint64) bool {
+			actual, err := Parse(binary.AppendUvarint(nil, x), comb)
+			return assert.NoError(t, err) && assert.Equal(t, x, actual)
+		})
+	})
+}
+
+func TestSLEB128(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[int64]{
+		{
+			comb: SLEB128[int64]("expected sleb128"),
+			cases: []testCase[int64]{
+				{
+					input:  []byte{},
+					output: 0,
+					err:    common.NewParseError(0, "expected sleb128"),
+				},
+				{
+					input:  []byte{0x02},
+					output: 2,
+				},
+				{
+					input:  []byte{0x7e},
+					output: -2,
+				},
+				{
+					input:  []byte{0xc0, 0xbb, 0x78},
+					output: -123456,
+				},
+				{
+					input:  []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f},
+					output: -1 << 63,
+				},
+				{
+					input:  []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00},
+					output: 1<<63 - 1,
+				},
+				{
+					input:  []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+					output: 0,
+					err:    common.NewParseError(0, "expected sleb128"),
+				},
+			},
+		},
+	})
+
+	runTests(t, []test[int8]{
+		{
+			comb: SLEB128[int8]("expected sleb128"),
+			cases: []testCase[int8]{
+				{
+					input:  []byte{0x80, 0x7f},
+					output: -128,
+				},
+				{
+					input:  []byte{0xff, 0x7e},
+					output: 0,
+					err:    common.NewParseError(0, "expected sleb128"),
+				},
+			},
+		},
+	})
+}
+
+func TestVarint(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[int32]{
+		{
+			comb: Varint[int32]("expected varint"),
+			cases: []testCase[int32]{
+				{
+					input:  []byte{0x00},
+					output: 0,
+				},
+				{
+					input:  []byte{0x01},
+					output: -1,
+				},
+				{
+					input:  []byte{0x02},
+					output: 1,
+				},
+				{
+					input:  []byte{0xfe, 0xff, 0xff, 0xff, 0x0f},
+					output: 1<<31 - 1,
+				},
+				{
+					input:  []byte{0xff, 0xff, 0xff, 0xff, 0x0f},
+					output: -1 << 31,
+				},
+				{
+					input:  []byte{0x80, 0x80, 0x80, 0x80, 0x10},
+					output: 0,
+					err:    common.NewParseError(0, "expected varint"),
+				},
+			},
+		},
+	})
+
+	t.Run("decode values encoded by encoding/binary", func(t *testing.T) {
+		t.Parallel()
+
+		seed := time.Now().UnixNano()
+		t.Logf("seed: %v", seed)
+		rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+		comb := Varint[int64]("E")
+
+		ohsnap.Check(t, 10_000, ohsnap.NewBuilder(rnd).Int64(), func(x int64) bool {
+			actual, err := Parse(binary.AppendVarint(nil, x), comb)
+			return assert.NoError(t, err) && assert.Equal(t, x, actual)
+		})
+	})
+}
+
+func TestQUICVarint(t *testing.T) {
+	t.Parallel()
+
+	// examples from RFC 9000, appendix A.1
+	runTests(t, []test[uint64]{
+		{
+			comb: QUICVarint[uint64]("expected quic varint"),
+			cases: []testCase[uint64]{
+				{
+					input:  []byte{0x25},
+					output: 37,
+				},
+				{
+					input:  []byte{0x40, 0x25},
+					output: 37,
+				},
+				{
+					input:  []byte{0x7b, 0xbd},
+					output: 15293,
+				},
+				{
+					input:  []byte{0x9d, 0x7f, 0x3e, 0x7d},
+					output: 494878333,
+				},
+				{
+					input:  []byte{0xc2, 0x19, 0x7c, 0x5e, 0xff, 0x14, 0xe8, 0x8c},
+					output: 151288809941952652,
+				},
+				{
+					input:  []byte{0x9d, 0x7f, 0x3e},
+					output: 0,
+					err:    common.NewParseError(0, "expected quic varint"),
+				},
+			},
+		},
+	})
+
+	runTests(t, []test[uint16]{
+		{
+			comb: QUICVarint[uint16]("expected quic varint"),
+			cases: []testCase[uint16]{
+				{
+					input:  []byte{0x7b, 0xbd},
+					output: 15293,
+				},
+				{
+					input:  []byte{0x9d, 0x7f, 0x3e, 0x7d},
+					output: 0,
+					err:    common.NewParseError(0, "expected quic varint"),
+				},
+			},
+		},
+	})
+}
+
+func TestCompactSize(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[uint64]{
+		{
+			comb: CompactSize[uint64]("expected compact size"),
+			cases: []testCase[uint64]{
+				{
+					input:  []byte{0xfc},
+					output: 0xfc,
+				},
+				{
+					input:  []byte{0xfd, 0xfd, 0x00},
+					output: 0xfd,
+				},
+				{
+					input:  []byte{0xfe, 0x01, 0x02, 0x03, 0x04},
+					output: 0x04030201,
+				},
+				{
+					input:  []byte{0xff, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+					output: 0x0807060504030201,
+				},
+				{
+					input:  []byte{0xfe, 0x01, 0x02},
+					output: 0,
+					err:    common.NewParseError(0, "expected compact size"),
+				},
+			},
+		},
+	})
+
+	runTests(t, []test[uint16]{
+		{
+			comb: CompactSize[uint16]("expected compact size"),
+			cases: []testCase[uint16]{
+				{
+					input:  []byte{0xfe, 0xff, 0xff, 0x00, 0x00},
+					output: 0xffff,
+				},
+				{
+					input:  []byte{0xfe, 0x00, 0x00, 0x01, 0x00},
+					output: 0,
+					err:    common.NewParseError(0, "expected compact size"),
+				},
+			},
+		},
+	})
+}
+
+func TestGitVarint(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[uint64]{
+		{
+			comb: GitVarint[uint64]("expected offset"),
+			cases: []testCase[uint64]{
+				{
+					input:  []byte{0x7f},
+					output: 127,
+				},
+				{
+					input:  []byte{0x80, 0x00},
+					output: 128,
+				},
+				{
+					input:  []byte{0x80, 0x80, 0x00},
+					output: 16512,
+				},
+				{
+					input:  []byte{0x81, 0x00},
+					output: 256,
+				},
+				{
+					input:  []byte{0x80},
+					output: 0,
+					err:    common.NewParseError(0, "expected offset"),
+				},
+				{
+					input: []byte{
+						0xff, 0xff, 0xff, 0xff, 0xff,
+						0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+					},
+					output: 0,
+					err:    common.NewParseError(0, "expected offset"),
+				},
+			},
+		},
+	})
+}
+
+func TestUTF8(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[rune]{
+		{
+			comb: UTF8("expected utf-8 character"),
+			cases: []testCase[rune]{
+				{
+					input:  []byte{},
+					output: 0,
+					err:    common.NewParseError(0, "expected utf-8 character"),
+				},
+				{
+					input:  []byte("a"),
+					output: 'a',
+				},
+				{
+					input:  []byte("ж"),
+					output: 'ж',
+				},
+				{
+					input:  []byte("€"),
+					output: '€',
+				},
+				{
+					input:  []byte("𝄞"),
+					output: '𝄞',
+				},
+				{
+					input:  []byte("�"),
+					output: '�',
+				},
+				{
+					input:  []byte{0xe2, 0x82},
+					output: 0,
+					err:    common.NewParseError(0, "expected utf-8 character"),
+				},
+				{
+					input:  []byte{0xc0, 0x80},
+					output: 0,
+					err:    common.NewParseError(0, "expected utf-8 character"),
+				},
+				{
+					input:  []byte{0xff},
+					output: 0,
+					err:    common.NewParseError(0, "expected utf-8 character"),
+				},
+			},
+		},
+	})
+
+	t.Run("decode random characters", func(t *testing.T) {
+		t.Parallel()
+
+		seed := time.Now().UnixNano()
+		t.Logf("seed: %v", seed)
+		rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+		comb := UTF8("E")
+
+		ohsnap.Check(t, 10_000, ohsnap.NewBuilder(rnd).Rune(), func(x rune) bool {
+			if !utf8.ValidRune(x) {
+				return true
+			}
+
+			actual, err := Parse(utf8.AppendRune(nil, x), comb)
+			return assert.NoError(t, err) && assert.Equal(t, x, actual)
+		})
+	})
+}
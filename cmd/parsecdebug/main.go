@@ -0,0 +1,391 @@
+// Parsecdebug steps through parsing of input by PEG or EBNF grammar file.
+//
+// Usage:
+//
+//	parsecdebug -grammar calc.peg -start expr [-syntax peg] [-color] input.txt
+//
+// Parsing is paused before the first rule, commands are read from standard input:
+// step into and over rules, run to the return of current rule or to the next breakpoint,
+// set breakpoints on names of rules or positions, print stack of rules in progress,
+// results of rules and source with current position. Type h for list of commands.
+//
+// Empty line repeats the previous command.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	stdstrings "strings"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/grammar"
+	"github.com/okneniz/parsec/strings"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "parsecdebug:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("parsecdebug", flag.ContinueOnError)
+
+	path := flags.String("grammar", "", "path to grammar file")
+	start := flags.String("start", "", "name of start rule (default: the first rule)")
+	syntax := flags.String("syntax", "", "syntax of grammar: peg or ebnf (default: by file extension)")
+	color := flags.Bool("color", isTerminal(stdout), "highlight current position by colors")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *path == "" {
+		return fmt.Errorf("-grammar flag is required")
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("path to input file is required")
+	}
+
+	g, err := loadGrammar(*path, *syntax)
+	if err != nil {
+		return err
+	}
+
+	if *start == "" {
+		*start = g.Rules()[0]
+	}
+
+	parser, err := g.Compile(*start, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *path, err)
+	}
+
+	input, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	s := &session{
+		debugger: common.NewDebugger(),
+		text:     []rune(string(input)),
+		out:      stdout,
+		color:    *color,
+	}
+
+	common.Debug(s.debugger, parser, strings.Buffer(s.text))
+	defer s.debugger.Stop()
+
+	return s.loop(stdin)
+}
+
+func loadGrammar(path, syntax string) (*grammar.Grammar, error) {
+	if syntax == "" {
+		syntax = "peg"
+
+		if stdstrings.EqualFold(filepath.Ext(path), ".ebnf") {
+			syntax = "ebnf"
+		}
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var g *grammar.Grammar
+
+	switch syntax {
+	case "peg":
+		g, err = grammar.ParsePEG(string(text))
+	case "ebnf":
+		g, err = grammar.ParseEBNF(string(text))
+	default:
+		return nil, fmt.Errorf("unknown syntax %q", syntax)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return g, nil
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// session - state of debugging session.
+type session struct {
+	debugger *common.Debugger
+	text     []rune
+	out      io.Writer
+	color    bool
+}
+
+const help = `commands:
+  s, step          go to the next call or return of rule
+  n, next          go to the next call or return of rule, skip nested rules
+  o, out           go to the return of current rule
+  c, continue      go to the next breakpoint
+  b NAME           break on calls of rule NAME
+  b LINE:COLUMN    break on calls of rules at position
+  d, delete        delete all breakpoints
+  i, info          list breakpoints
+  bt, stack        print stack of rules in progress
+  p, print         print current event with result of rule
+  l, list          print source with current position
+  q, quit          stop parsing and exit
+`
+
+func (s *session) loop(stdin io.Reader) error {
+	scanner := bufio.NewScanner(stdin)
+	previous := ""
+
+	fmt.Fprintf(s.out, "parsing is paused before the first rule, type h for help\n")
+
+	for {
+		fmt.Fprint(s.out, "(parsec) ")
+
+		if !scanner.Scan() {
+			fmt.Fprintln(s.out)
+			return scanner.Err()
+		}
+
+		line := stdstrings.TrimSpace(scanner.Text())
+		if line == "" {
+			line = previous
+		}
+
+		previous = line
+
+		fields := stdstrings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "s", "step":
+			s.resume(s.debugger.Step)
+		case "n", "next":
+			s.resume(s.debugger.Next)
+		case "o", "out":
+			s.resume(s.debugger.Out)
+		case "c", "continue":
+			s.resume(s.debugger.Continue)
+		case "b", "break":
+			s.breakpoint(fields[1:])
+		case "d", "delete":
+			s.debugger.ClearBreakpoints()
+			fmt.Fprintln(s.out, "breakpoints deleted")
+		case "i", "info":
+			for _, x := range s.debugger.Breakpoints() {
+				fmt.Fprintln(s.out, x)
+			}
+		case "bt", "stack":
+			s.stack()
+		case "p", "print":
+			s.print(s.debugger.Current())
+		case "l", "list":
+			s.list(s.debugger.Current())
+		case "h", "help":
+			fmt.Fprint(s.out, help)
+		case "q", "quit":
+			return nil
+		default:
+			fmt.Fprintf(s.out, "unknown command %q, type h for help\n", fields[0])
+		}
+	}
+}
+
+func (s *session) resume(f func() (common.Event, bool)) {
+	if s.debugger.Done() {
+		s.finished()
+		return
+	}
+
+	event, ok := f()
+	if !ok {
+		s.finished()
+		return
+	}
+
+	s.print(event)
+	s.list(event)
+}
+
+func (s *session) finished() {
+	result, err := s.debugger.Result()
+	if err != nil {
+		fmt.Fprintf(s.out, "parsing failed: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(s.out, "parsing finished: %s\n", formatResult(result))
+}
+
+func (s *session) breakpoint(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(s.out, "usage: b NAME or b LINE:COLUMN")
+		return
+	}
+
+	var line, column int
+	if n, _ := fmt.Sscanf(args[0], "%d:%d", &line, &column); n == 2 {
+		index, ok := s.index(line, column)
+		if !ok {
+			fmt.Fprintf(s.out, "position %s is out of input\n", args[0])
+			return
+		}
+
+		s.debugger.BreakAt(index)
+		fmt.Fprintf(s.out, "breakpoint at %d:%d\n", line, column)
+		return
+	}
+
+	s.debugger.BreakOnRule(args[0])
+	fmt.Fprintf(s.out, "breakpoint on rule %s\n", args[0])
+}
+
+// index - index of rune at line and column, which start from 1.
+func (s *session) index(line, column int) (int, bool) {
+	l, c := 1, 1
+
+	for i, x := range s.text {
+		if l == line && c == column {
+			return i, true
+		}
+
+		if x == '\n' {
+			l, c = l+1, 1
+		} else {
+			c++
+		}
+	}
+
+	return len(s.text), l == line && c == column
+}
+
+func (s *session) stack() {
+	stack := s.debugger.Stack()
+	if len(stack) == 0 {
+		fmt.Fprintln(s.out, "stack is empty")
+		return
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		fmt.Fprintf(s.out, "#%d %s at %s\n", len(stack)-1-i, stack[i].Name, location(stack[i].Start))
+	}
+}
+
+func (s *session) print(event common.Event) {
+	if event.Name == "" {
+		fmt.Fprintln(s.out, "parsing is not paused on rule")
+		return
+	}
+
+	indent := stdstrings.Repeat("  ", event.Depth)
+
+	switch event.Kind {
+	case common.EventEnter:
+		fmt.Fprintf(s.out, "%s> %s at %s\n", indent, event.Name, location(event.Start))
+	case common.EventExit:
+		fmt.Fprintf(
+			s.out,
+			"%s< %s at %s: %s\n",
+			indent,
+			event.Name,
+			location(event.End),
+			formatResult(event.Result),
+		)
+	case common.EventBacktrack:
+		fmt.Fprintf(s.out, "%s! %s at %s: %v\n", indent, event.Name, location(event.Start), event.Err)
+	}
+}
+
+// list - print line of source with current position marked by caret.
+func (s *session) list(event common.Event) {
+	pos, ok := event.Start.(strings.Position)
+	if !ok {
+		return
+	}
+
+	if event.Kind == common.EventExit {
+		pos, _ = event.End.(strings.Position)
+	}
+
+	from := pos.Index()
+	for from > 0 && s.text[from-1] != '\n' {
+		from--
+	}
+
+	to := pos.Index()
+	for to < len(s.text) && s.text[to] != '\n' {
+		to++
+	}
+
+	prefix := fmt.Sprintf("%4d | ", pos.Line()+1)
+	before := string(s.text[from:pos.Index()])
+
+	if pos.Index() < to && s.color {
+		// reverse video of rune at current position
+		fmt.Fprintf(
+			s.out,
+			"%s%s\x1b[7m%c\x1b[0m%s\n",
+			prefix,
+			before,
+			s.text[pos.Index()],
+			string(s.text[pos.Index()+1:to]),
+		)
+	} else {
+		fmt.Fprintf(s.out, "%s%s\n", prefix, string(s.text[from:to]))
+	}
+
+	fmt.Fprintf(s.out, "%s^\n", stdstrings.Repeat(" ", len(prefix)+len([]rune(before))))
+}
+
+// location - position as line:column, which start from 1.
+func location(pos any) string {
+	if x, ok := pos.(strings.Position); ok {
+		return fmt.Sprintf("%d:%d", x.Line()+1, x.Column()+1)
+	}
+
+	return fmt.Sprint(pos)
+}
+
+// formatResult - format parse trees of rules as names with matched text.
+func formatResult(result any) string {
+	switch x := result.(type) {
+	case []*grammar.Node:
+		items := make([]string, len(x))
+		for i, node := range x {
+			items[i] = formatResult(node)
+		}
+
+		return stdstrings.Join(items, ", ")
+	case *grammar.Node:
+		if len(x.Children) == 0 {
+			return fmt.Sprintf("%s %q", x.Name, x.Text)
+		}
+
+		return fmt.Sprintf("%s %q [%s]", x.Name, x.Text, formatResult(x.Children))
+	default:
+		return fmt.Sprintf("%v", x)
+	}
+}
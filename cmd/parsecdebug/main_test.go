@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "list.peg")
+	err := os.WriteFile(path, []byte(`
+		List   <- '[' Number (',' _ Number)* ']'
+		Number <- [0-9]+
+		_      <- [ \n]*
+	`), 0o644)
+	assert.NoError(t, err)
+
+	input := filepath.Join(dir, "input.txt")
+	err = os.WriteFile(input, []byte("[1,\n23]"), 0o644)
+	assert.NoError(t, err)
+
+	t.Run("session", func(t *testing.T) {
+		t.Parallel()
+
+		commands := strings.Join([]string{
+			"b 2:1",
+			"i",
+			"c",
+			"bt",
+			"o",
+			"",
+			"d",
+			"c",
+			"q",
+		}, "\n")
+
+		out := new(bytes.Buffer)
+		err := run([]string{"-grammar", path, input}, strings.NewReader(commands), out)
+		assert.NoError(t, err)
+
+		assert.Equal(t, `parsing is paused before the first rule, type h for help
+(parsec) breakpoint at 2:1
+(parsec) index 4
+(parsec)   > Number at 2:1
+   2 | 23]
+       ^
+(parsec) #0 Number at 2:1
+#1 List at 1:1
+(parsec)   < Number at 2:3: Number "23"
+   2 | 23]
+         ^
+(parsec) < List at 2:4: List "[1,\n23]" [Number "1", _ "\n", Number "23"]
+   2 | 23]
+          ^
+(parsec) breakpoints deleted
+(parsec) parsing finished: List "[1,\n23]" [Number "1", _ "\n", Number "23"]
+(parsec) `, out.String())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		bad := filepath.Join(dir, "bad.txt")
+		err := os.WriteFile(bad, []byte("[1,x]"), 0o644)
+		assert.NoError(t, err)
+
+		out := new(bytes.Buffer)
+		err = run([]string{"-grammar", path, "-color", bad}, strings.NewReader("b Number\nc\nc\ns\nl\nc\n"), out)
+		assert.NoError(t, err)
+
+		assert.Contains(t, out.String(), "(parsec)   ! Number at 1:4: Parse error at line=0 column=3 index=3: expected Number\n")
+		assert.Contains(t, out.String(), "   1 | [1,\x1b[7mx\x1b[0m]\n          ^\n")
+		assert.Contains(t, out.String(), "parsing failed: Parse error at line=0 column=3 index=3: expected [ \\n] or [0-9]\n")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := run([]string{input}, strings.NewReader(""), new(bytes.Buffer))
+		assert.EqualError(t, err, "-grammar flag is required")
+
+		err = run([]string{"-grammar", path}, strings.NewReader(""), new(bytes.Buffer))
+		assert.EqualError(t, err, "path to input file is required")
+
+		err = run([]string{"-grammar", path, "-start", "Value", input}, strings.NewReader(""), new(bytes.Buffer))
+		assert.ErrorContains(t, err, "undefined rule Value")
+
+		err = run([]string{"-grammar", path, "-syntax", "yacc", input}, strings.NewReader(""), new(bytes.Buffer))
+		assert.EqualError(t, err, `unknown syntax "yacc"`)
+	})
+}
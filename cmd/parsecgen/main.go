@@ -0,0 +1,120 @@
+// Parsecgen generates Go parser from PEG or EBNF grammar file.
+//
+// Usage:
+//
+//	parsecgen -in calc.peg [-out calc.go] [-package calc] [-prefix calc] [-syntax peg]
+//
+// It's designed to be used with go generate:
+//
+//	//go:generate go run github.com/okneniz/parsec/cmd/parsecgen -in calc.peg
+//
+// Package name is taken from GOPACKAGE environment variable set by go generate
+// if -package flag is not passed. Syntax of grammar is detected by file extension,
+// .ebnf files are parsed as EBNF, all others as PEG.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/okneniz/parsec/grammar"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "parsecgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("parsecgen", flag.ContinueOnError)
+
+	in := flags.String("in", "", "path to grammar file")
+	out := flags.String("out", "", "path to generated file (default: grammar file with .go extension)")
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "package name of generated file")
+	prefix := flags.String("prefix", "", "prefix of unexported identifiers (default: name of grammar file)")
+	syntax := flags.String("syntax", "", "syntax of grammar: peg or ebnf (default: by file extension)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" {
+		return fmt.Errorf("-in flag is required")
+	}
+
+	if *pkg == "" {
+		return fmt.Errorf("-package flag is required outside of go generate")
+	}
+
+	base := strings.TrimSuffix(filepath.Base(*in), filepath.Ext(*in))
+
+	if *out == "" {
+		*out = strings.TrimSuffix(*in, filepath.Ext(*in)) + ".go"
+	}
+
+	if *prefix == "" {
+		*prefix = identifier(base)
+	}
+
+	if *syntax == "" {
+		*syntax = "peg"
+
+		if strings.EqualFold(filepath.Ext(*in), ".ebnf") {
+			*syntax = "ebnf"
+		}
+	}
+
+	text, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+
+	var g *grammar.Grammar
+
+	switch *syntax {
+	case "peg":
+		g, err = grammar.ParsePEG(string(text))
+	case "ebnf":
+		g, err = grammar.ParseEBNF(string(text))
+	default:
+		return fmt.Errorf("unknown syntax %q", *syntax)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", *in, err)
+	}
+
+	source, err := g.GenerateGo(grammar.GoOptions{
+		Package: *pkg,
+		Prefix:  *prefix,
+		Source:  filepath.Base(*in),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", *in, err)
+	}
+
+	return os.WriteFile(*out, source, 0o644)
+}
+
+// identifier - make unexported Go identifier from file name.
+func identifier(name string) string {
+	result := make([]rune, 0, len(name))
+
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			result = append(result, unicode.ToLower(r))
+		}
+	}
+
+	if len(result) == 0 || unicode.IsDigit(result[0]) {
+		return "grammar" + string(result)
+	}
+
+	return string(result)
+}
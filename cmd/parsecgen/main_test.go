@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	in := filepath.Join(dir, "list-of-numbers.ebnf")
+	err := os.WriteFile(in, []byte(`list = "[", [ digit, { ",", digit } ], "]" ; digit = "0" | "1" ;`), 0o644)
+	assert.NoError(t, err)
+
+	err = run([]string{"-in", in, "-package", "numbers"})
+	assert.NoError(t, err)
+
+	source, err := os.ReadFile(filepath.Join(dir, "list-of-numbers.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "// Code generated by parsecgen from list-of-numbers.ebnf. DO NOT EDIT.")
+	assert.Contains(t, string(source), "package numbers")
+	assert.Contains(t, string(source), "func ParseList(")
+	assert.Contains(t, string(source), "var listofnumbersListRule ")
+
+	err = run([]string{"-in", in, "-package", "numbers", "-syntax", "peg"})
+	assert.ErrorContains(t, err, "list-of-numbers.ebnf: Parse error")
+
+	err = run([]string{"-in", in, "-package", "numbers", "-syntax", "yacc"})
+	assert.EqualError(t, err, `unknown syntax "yacc"`)
+
+	err = run([]string{"-package", "numbers"})
+	assert.EqualError(t, err, "-in flag is required")
+}
@@ -15,14 +15,16 @@ func Chainl[T any, P any, S any](
 ) Combinator[T, P, S] {
 	parse := Chainl1(c, op)
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		result, err := parse(buffer)
 		if err != nil {
 			return def, nil
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy, DescriptionOf(c), DescriptionOf(op))
+	})
 }
 
 // Chainl1 - read one or more occurrences of data readed by c combinator,
@@ -36,7 +38,7 @@ func Chainl1[T any, P any, S any](
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		x, err := c(buffer)
 		if err != nil {
 			return null, err
@@ -59,7 +61,9 @@ func Chainl1[T any, P any, S any](
 		}
 
 		return rest, nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy1, DescriptionOf(c), DescriptionOf(op))
+	})
 }
 
 // Chainr - read zero or more occurrences of data readed by c combinator,
@@ -74,14 +78,16 @@ func Chainr[T any, P any, S any](
 ) Combinator[T, P, S] {
 	f := Chainr1(c, op)
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		result, err := f(buffer)
 		if err != nil {
 			return def, nil
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy, DescriptionOf(c), DescriptionOf(op))
+	})
 }
 
 // Chainr1 - read one or more occurrences of data readed by c combinator,
@@ -95,7 +101,7 @@ func Chainr1[T any, P any, S any](
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		x, err := c(buffer)
 		if err != nil {
 			return null, err
@@ -136,7 +142,9 @@ func Chainr1[T any, P any, S any](
 		}
 
 		return chain[0], nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy1, DescriptionOf(c), DescriptionOf(op))
+	})
 }
 
 // SepBy - read zero or more occurrences of data readed by c combinator,
@@ -155,7 +163,7 @@ func SepBy[T any, P any, S any, B any](
 		),
 	)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		token, err := body(buffer)
@@ -174,7 +182,51 @@ func SepBy[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy, DescriptionOf(body), DescriptionOf(sep))
+	})
+}
+
+// SepByFold - like SepBy, but combine data which returned by body combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value. Doesn't allocate memory for items.
+// The same init value is used for each parsing, so it should not be mutated by f.
+func SepByFold[T any, P any, S any, B any, A any](
+	init A,
+	body Combinator[T, P, S],
+	sep Combinator[T, P, B],
+	f func(A, S) A,
+) Combinator[T, P, A] {
+	c := Try(
+		And(
+			sep,
+			body,
+			func(_ B, x S) S { return x },
+		),
+	)
+
+	return describe(func(buffer Buffer[T, P]) (A, Error[P]) {
+		result := init
+
+		token, err := body(buffer)
+		if err != nil {
+			return result, nil
+		}
+		result = f(result, token)
+
+		for !buffer.IsEOF() {
+			token, err = c(buffer)
+			if err != nil {
+				break
+			}
+
+			result = f(result, token)
+		}
+
+		return result, nil
+	}, func() *Description {
+		return nested(KindSepBy, DescriptionOf(body), DescriptionOf(sep))
+	})
 }
 
 // SepBy1 - read one or more occurrences of data readed by c combinator,
@@ -188,7 +240,7 @@ func SepBy1[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	parse := SepBy(cap, body, sep)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		pos := buffer.Position()
 
 		// ignore error because SepBy return empty list anyway
@@ -198,7 +250,9 @@ func SepBy1[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSepBy1, DescriptionOf(body), DescriptionOf(sep))
+	})
 }
 
 // EndBy - read zero or more occurrences of data readed by c combinator,
@@ -211,7 +265,7 @@ func EndBy[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	c := Try(SkipAfter(sep, body))
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for !buffer.IsEOF() {
@@ -224,7 +278,9 @@ func EndBy[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindMany, nested(KindSequence, DescriptionOf(body), DescriptionOf(sep)))
+	})
 }
 
 // EndBy1 - read one or more occurrences of data readed by c combinator,
@@ -238,7 +294,7 @@ func EndBy1[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	c := EndBy(cap, body, sep)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		pos := buffer.Position()
 
 		// ignore error because EndBy return empty list anyway
@@ -248,7 +304,9 @@ func EndBy1[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSome, nested(KindSequence, DescriptionOf(body), DescriptionOf(sep)))
+	})
 }
 
 // SepEndBy - read zero or more occurrences of data readed by body combinator,
@@ -261,7 +319,7 @@ func SepEndBy[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	s := Try(sep)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for !buffer.IsEOF() {
@@ -279,7 +337,13 @@ func SepEndBy[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(
+			KindSequence,
+			nested(KindSepBy, DescriptionOf(body), DescriptionOf(sep)),
+			nested(KindOptional, DescriptionOf(sep)),
+		)
+	})
 }
 
 // SepEndBy1 - read one or more occurrences of data readed by body combinator,
@@ -293,7 +357,7 @@ func SepEndBy1[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	c := SepEndBy(cap, body, sep)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		pos := buffer.Position()
 
 		// ignore error because SepEndBy return empty list anyway
@@ -303,7 +367,13 @@ func SepEndBy1[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(
+			KindSequence,
+			nested(KindSepBy1, DescriptionOf(body), DescriptionOf(sep)),
+			nested(KindOptional, DescriptionOf(sep)),
+		)
+	})
 }
 
 // ManyTill - accumulate data readed by c combinator until combinantor end succeeds.
@@ -316,7 +386,7 @@ func ManyTill[T any, P any, S any, B any](
 ) Combinator[T, P, []S] {
 	needStop := Try(end)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for !buffer.IsEOF() {
@@ -336,5 +406,7 @@ func ManyTill[T any, P any, S any, B any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, nested(KindMany, DescriptionOf(c)), DescriptionOf(end))
+	})
 }
@@ -10,7 +10,7 @@ func Satisfy[T any, P any](
 ) Combinator[T, P, T] {
 	var null T
 
-	return func(buffer Buffer[T, P]) (T, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (T, Error[P]) {
 		pos := buffer.Position()
 
 		token, err := buffer.Read(greedy)
@@ -23,14 +23,16 @@ func Satisfy[T any, P any](
 		}
 
 		return null, NewParseError(pos, errMessage)
-	}
+	}, func() *Description {
+		return class(errMessage, f)
+	})
 }
 
 // Any - returns the readed item.
 func Any[T any, P any]() Combinator[T, P, T] {
 	var null T
 
-	return func(buffer Buffer[T, P]) (T, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (T, Error[P]) {
 		pos := buffer.Position()
 
 		token, err := buffer.Read(true)
@@ -39,14 +41,16 @@ func Any[T any, P any]() Combinator[T, P, T] {
 		}
 
 		return token, nil
-	}
+	}, func() *Description {
+		return class("any", Anything[T])
+	})
 }
 
 // Try - try to use c combinator, if it falls, it returns buffer to the previous position.
 func Try[T any, P any, S any](c Combinator[T, P, S]) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		pos := buffer.Position()
 
 		result, err := c(buffer)
@@ -63,7 +67,57 @@ func Try[T any, P any, S any](c Combinator[T, P, S]) Combinator[T, P, S] {
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return DescriptionOf(c)
+	})
+}
+
+// LookAhead - parse data by c combinator without consuming input,
+// returns buffer to the previous position in any case.
+func LookAhead[T any, P any, S any](c Combinator[T, P, S]) Combinator[T, P, S] {
+	var null S
+
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
+		pos := buffer.Position()
+
+		result, err := c(buffer)
+
+		if seekErr := buffer.Seek(pos); seekErr != nil {
+			return null, NewParseError(pos, seekErr.Error())
+		}
+
+		if err != nil {
+			return null, err
+		}
+
+		return result, nil
+	}, func() *Description {
+		return nested(KindLookAhead, DescriptionOf(c))
+	})
+}
+
+// NotFollowedBy - succeeds only if c combinator fails, doesn't consume input.
+func NotFollowedBy[T any, P any, S any](
+	errMessage string,
+	c Combinator[T, P, S],
+) Combinator[T, P, bool] {
+	return describe(func(buffer Buffer[T, P]) (bool, Error[P]) {
+		pos := buffer.Position()
+
+		_, err := c(buffer)
+
+		if seekErr := buffer.Seek(pos); seekErr != nil {
+			return false, NewParseError(pos, seekErr.Error())
+		}
+
+		if err == nil {
+			return false, NewParseError(pos, errMessage)
+		}
+
+		return true, nil
+	}, func() *Description {
+		return nested(KindNotFollowedBy, DescriptionOf(c))
+	})
 }
 
 // Between - parse sequence of input combinators, skip first and last results.
@@ -74,7 +128,7 @@ func Between[T any, P any, S any, B any, M any](
 ) Combinator[T, P, B] {
 	var null B
 
-	return func(buffer Buffer[T, P]) (B, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (B, Error[P]) {
 		_, err := pre(buffer)
 		if err != nil {
 			return null, err
@@ -91,18 +145,22 @@ func Between[T any, P any, S any, B any, M any](
 		}
 
 		return body, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, DescriptionOf(pre), DescriptionOf(c), DescriptionOf(suf))
+	})
 }
 
 // EOF - checks that buffer reading has finished.
 func EOF[T any, P any]() Combinator[T, P, bool] {
-	return func(buffer Buffer[T, P]) (bool, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (bool, Error[P]) {
 		if buffer.IsEOF() {
 			return true, nil
 		}
 
 		return false, nil
-	}
+	}, func() *Description {
+		return nested(KindEOF)
+	})
 }
 
 // Cast - parse data by c combinator and apply to f function.
@@ -113,7 +171,7 @@ func Cast[T any, P any, S any, B any](
 ) Combinator[T, P, B] {
 	var null B
 
-	return func(buffer Buffer[T, P]) (B, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (B, Error[P]) {
 		pos := buffer.Position()
 
 		result, err := c(buffer)
@@ -127,7 +185,9 @@ func Cast[T any, P any, S any, B any](
 		}
 
 		return value, nil
-	}
+	}, func() *Description {
+		return DescriptionOf(c)
+	})
 }
 
 // Const - doesn't read anything, just return the input value.
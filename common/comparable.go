@@ -1,5 +1,9 @@
 package common
 
+import (
+	"strings"
+)
+
 // Eq - succeeds for any item which equal input t.
 // Returns the item that is actually readed from input buffer.
 // Greedy by default - keep position after reading.
@@ -7,8 +11,10 @@ func Eq[T comparable, P any](
 	errMessage string,
 	t T,
 ) Combinator[T, P, T] {
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	return describe(Satisfy[T, P](errMessage, true, func(x T) bool {
 		return t == x
+	}), func() *Description {
+		return terminal(t)
 	})
 }
 
@@ -19,8 +25,12 @@ func NotEq[T comparable, P any](
 	errMessage string,
 	t T,
 ) Combinator[T, P, T] {
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	f := func(x T) bool {
 		return t != x
+	}
+
+	return describe(Satisfy[T, P](errMessage, true, f), func() *Description {
+		return class("not "+literal([]T{t}), Condition[T](f))
 	})
 }
 
@@ -36,9 +46,11 @@ func OneOf[T comparable, P any](
 		m[x] = struct{}{}
 	}
 
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	return describe(Satisfy[T, P](errMessage, true, func(x T) bool {
 		_, exists := m[x]
 		return exists
+	}), func() *Description {
+		return oneOf(data)
 	})
 }
 
@@ -54,9 +66,13 @@ func NoneOf[T comparable, P any](
 		m[x] = struct{}{}
 	}
 
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	f := func(x T) bool {
 		_, exists := m[x]
 		return !exists
+	}
+
+	return describe(Satisfy[T, P](errMessage, true, f), func() *Description {
+		return class("none of "+strings.Join(literals(data), ", "), Condition[T](f))
 	})
 }
 
@@ -67,7 +83,7 @@ func SequenceOf[T comparable, P any](
 	errMessage string,
 	data ...T,
 ) Combinator[T, P, []T] {
-	return func(buffer Buffer[T, P]) ([]T, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]T, Error[P]) {
 		pos := buffer.Position()
 
 		result := make([]T, 0, len(data))
@@ -86,7 +102,9 @@ func SequenceOf[T comparable, P any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return terminal(data...)
+	})
 }
 
 // Map - Reads one element from the input buffer using the combinator,
@@ -100,7 +118,9 @@ func Map[T any, P any, K comparable, V any](
 ) Combinator[T, P, V] {
 	var null V
 
-	return func(buffer Buffer[T, P]) (V, Error[P]) {
+	point, branches := newMapCoveragePoint(errMessage, cases)
+
+	return describe(func(buffer Buffer[T, P]) (V, Error[P]) {
 		pos := buffer.Position()
 
 		token, err := c(buffer)
@@ -113,8 +133,14 @@ func Map[T any, P any, K comparable, V any](
 			return null, NewParseError(pos, errMessage)
 		}
 
+		if point != nil {
+			hitCoverage(point, branches[token])
+		}
+
 		return result, nil
-	}
+	}, func() *Description {
+		return DescriptionOf(c)
+	})
 }
 
 // MapAs - Read one element from the input buffer using the combinator,
@@ -158,11 +184,31 @@ func MapTree[T comparable, P any, K comparable, V any](
 	cases map[T]Combinator[K, P, V],
 	split func(T) []K,
 ) Combinator[K, P, V] {
-	tree := NewLongestPrefixTree(cases, split)
+	point, branches := newMapCoveragePoint(errMessage, cases)
+
+	covered := cases
+	if point != nil {
+		covered = make(map[T]Combinator[K, P, V], len(cases))
+
+		for key, c := range cases {
+			branch := branches[key]
+
+			covered[key] = func(buffer Buffer[K, P]) (V, Error[P]) {
+				result, err := c(buffer)
+				if err == nil {
+					hitCoverage(point, branch)
+				}
+
+				return result, err
+			}
+		}
+	}
+
+	tree := NewLongestPrefixTree(covered, split)
 
 	var null V
 
-	return func(buf Buffer[K, P]) (V, Error[P]) {
+	return describe(func(buf Buffer[K, P]) (V, Error[P]) {
 		pos := buf.Position()
 
 		parse, err := tree.Lookup(buf)
@@ -175,5 +221,7 @@ func MapTree[T comparable, P any, K comparable, V any](
 		}
 
 		return null, NewParseError(pos, errMessage)
-	}
+	}, func() *Description {
+		return mapTreeDescription(cases, split)
+	})
 }
@@ -6,7 +6,7 @@ func Concat[T any, P any, S any](
 	cap int,
 	cs ...Combinator[T, P, []S],
 ) Combinator[T, P, []S] {
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for _, c := range cs {
@@ -19,7 +19,9 @@ func Concat[T any, P any, S any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, descriptionsOf(cs...)...)
+	})
 }
 
 // Sequence - reads input elements one by one using cs combinators.
@@ -28,7 +30,7 @@ func Sequence[T any, P any, S any](
 	cap int,
 	cs ...Combinator[T, P, S],
 ) Combinator[T, P, []S] {
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for _, c := range cs {
@@ -41,7 +43,9 @@ func Sequence[T any, P any, S any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, descriptionsOf(cs...)...)
+	})
 }
 
 // Choice - searches for a combinator that works successfully on the input data.
@@ -52,13 +56,18 @@ func Choice[T any, P any, S any](
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	point := newCoveragePoint("choice", errMessage, func() []string {
+		return alternativeLabels(len(cs))
+	})
+
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		previous := make([]Error[P], 0)
 		pos := buffer.Position()
 
-		for _, c := range cs {
+		for i, c := range cs {
 			result, err := c(buffer)
 			if err == nil {
+				hitCoverage(point, i)
 				return result, err
 			}
 
@@ -66,73 +75,85 @@ func Choice[T any, P any, S any](
 		}
 
 		return null, NewParseError(pos, errMessage, previous...)
-	}
+	}, func() *Description {
+		return nested(KindChoice, descriptionsOf(cs...)...)
+	})
 }
 
 // Skip - ignores the result of the first combinator
 // and returns only the result of the second.
+// Skipped items are trivia of Lossless syntax tree.
 func Skip[T any, P any, S any, B any](
 	skip Combinator[T, P, B],
 	next Combinator[T, P, S],
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
-		_, err := skip(buffer)
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
+		_, err := skipTrivia(buffer, skip)
 		if err != nil {
 			return null, err
 		}
 
 		return next(buffer)
-	}
+	}, func() *Description {
+		return nested(KindSequence, DescriptionOf(skip), DescriptionOf(next))
+	})
 }
 
 // SkipAfter - ignores the result of the first combinator
 // and returns only the result of the second.
 // Use body combinator at first.
+// Skipped items are trivia of Lossless syntax tree.
 func SkipAfter[T any, P any, S any, B any](
 	skip Combinator[T, P, B],
 	body Combinator[T, P, S],
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		result, err := body(buffer)
 		if err != nil {
 			return null, err
 		}
 
-		_, err = skip(buffer)
+		_, err = skipTrivia(buffer, skip)
 		if err != nil {
 			return null, err
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, DescriptionOf(body), DescriptionOf(skip))
+	})
 }
 
 // SkipMany - skip sequence of items parsed by first combinator before body combinator.
 // Do it without any additional allocation like in `Many` combinator.
+// Skipped items are trivia of Lossless syntax tree.
 func SkipMany[T any, P any, S any, B any](
 	skip Combinator[T, P, S],
 	body Combinator[T, P, B],
 ) Combinator[T, P, B] {
 	skip = Try(skip)
 
-	return func(buffer Buffer[T, P]) (B, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (B, Error[P]) {
 		for !buffer.IsEOF() {
-			_, err := skip(buffer)
+			_, err := skipTrivia(buffer, skip)
 			if err != nil {
 				break
 			}
 		}
 
 		return body(buffer)
-	}
+	}, func() *Description {
+		return nested(KindSequence, nested(KindMany, DescriptionOf(skip)), DescriptionOf(body))
+	})
 }
 
 // Padded - skip sequence of items parsed by first combinator
 // before and after body combinator.
+// Skipped items are trivia of Lossless syntax tree.
 func Padded[T any, P any, S any, B any](
 	skip Combinator[T, P, S],
 	body Combinator[T, P, B],
@@ -141,9 +162,9 @@ func Padded[T any, P any, S any, B any](
 
 	var null B
 
-	return func(buffer Buffer[T, P]) (B, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (B, Error[P]) {
 		for !buffer.IsEOF() {
-			_, err := skip(buffer)
+			_, err := skipTrivia(buffer, skip)
 			if err != nil {
 				break
 			}
@@ -155,21 +176,28 @@ func Padded[T any, P any, S any, B any](
 		}
 
 		for !buffer.IsEOF() {
-			_, err := skip(buffer)
+			_, err := skipTrivia(buffer, skip)
 			if err != nil {
 				break
 			}
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(
+			KindSequence,
+			nested(KindMany, DescriptionOf(skip)),
+			DescriptionOf(body),
+			nested(KindMany, DescriptionOf(skip)),
+		)
+	})
 }
 
 // SkipSequence - reads input elements one by one using `cs` combinators and ignore it.
 func SkipSequence[T, P, S any](combs ...Combinator[T, P, S]) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		for _, c := range combs {
 			_, err := c(buffer)
 			if err != nil {
@@ -178,7 +206,9 @@ func SkipSequence[T, P, S any](combs ...Combinator[T, P, S]) Combinator[T, P, S]
 		}
 
 		return null, nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, descriptionsOf(combs...)...)
+	})
 }
 
 // SkipSequenceOf - reads input elements which must be equal input data and ignore it.
@@ -189,7 +219,7 @@ func SkipSequenceOf[T comparable, P, S any](
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		pos := buffer.Position()
 
 		for _, x := range data {
@@ -203,5 +233,7 @@ func SkipSequenceOf[T comparable, P, S any](
 		}
 
 		return null, nil
-	}
+	}, func() *Description {
+		return terminal(data...)
+	})
 }
@@ -0,0 +1,412 @@
+package common
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CoverageBranch - branch of instrumented combinator,
+// for example alternative of Choice or entry of map.
+type CoverageBranch struct {
+	// Label - description of branch.
+	Label string
+	// Count - how many times the branch is used.
+	Count int
+}
+
+// CoveragePoint - instrumented combinator with branches.
+type CoveragePoint struct {
+	// Kind - kind of combinator: choice, optional, map or named.
+	Kind string
+	// Location - file and line where combinator is made.
+	Location string
+	// Name - name of Named combinator or error message of other combinators.
+	Name string
+	// Branches - branches of combinator in order of declaration,
+	// entries of maps are sorted by keys.
+	Branches []CoverageBranch
+}
+
+// Covered - count of branches used at least once.
+func (p CoveragePoint) Covered() int {
+	count := 0
+
+	for _, x := range p.Branches {
+		if x.Count > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+type coveragePoint struct {
+	kind     string
+	location string
+	name     string
+	branches []string
+}
+
+// registry - all instrumented combinators made by process,
+// combinators made in the same place with the same branches share point.
+var registry struct {
+	mu     sync.Mutex
+	points []*coveragePoint
+	index  map[string]*coveragePoint
+}
+
+var activeCoverage atomic.Pointer[Coverage]
+
+// Coverage - counters of branches of Choice, Optional, Map, MapTree
+// and Named combinators used during parsing (see EnableCoverage).
+type Coverage struct {
+	mu     sync.Mutex
+	counts map[*coveragePoint][]int
+}
+
+// NewCoverage - make empty coverage.
+func NewCoverage() *Coverage {
+	c := new(Coverage)
+	c.counts = make(map[*coveragePoint][]int)
+	return c
+}
+
+// EnableCoverage - count branches of combinators used by parsers in c.
+// Only combinators made while coverage is enabled are instrumented,
+// so coverage must be enabled before making of parsers.
+func EnableCoverage(c *Coverage) {
+	activeCoverage.Store(c)
+}
+
+// DisableCoverage - stop counting enabled by EnableCoverage.
+func DisableCoverage() {
+	activeCoverage.Store(nil)
+}
+
+// newCoveragePoint - register combinator in place where it's made by user code,
+// returns nil without registration if coverage is disabled.
+func newCoveragePoint(kind, name string, labels func() []string) *coveragePoint {
+	if activeCoverage.Load() == nil {
+		return nil
+	}
+
+	location := callerLocation()
+	branches := labels()
+	key := strings.Join(append([]string{kind, location, name}, branches...), "\x00")
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if point, exists := registry.index[key]; exists {
+		return point
+	}
+
+	point := &coveragePoint{
+		kind:     kind,
+		location: location,
+		name:     name,
+		branches: branches,
+	}
+
+	if registry.index == nil {
+		registry.index = make(map[string]*coveragePoint)
+	}
+
+	registry.index[key] = point
+	registry.points = append(registry.points, point)
+
+	return point
+}
+
+// libraryPackages - packages which calls are skipped to find location of combinator.
+var libraryPackages = func() []string {
+	root := path.Dir(reflect.TypeOf(coveragePoint{}).PkgPath())
+
+	result := make([]string, 0, 5)
+	for _, name := range []string{"common", "strings", "bytes", "tokens", "lexer"} {
+		result = append(result, root+"/"+name+".")
+	}
+
+	return result
+}()
+
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		if !isLibraryFrame(frame) {
+			dir := filepath.Base(filepath.Dir(frame.File))
+			return fmt.Sprintf("%s/%s:%d", dir, filepath.Base(frame.File), frame.Line)
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+func isLibraryFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+
+	for _, prefix := range libraryPackages {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hitCoverage - count branch of point if coverage is enabled.
+func hitCoverage(point *coveragePoint, branch int) {
+	if point == nil {
+		return
+	}
+
+	c := activeCoverage.Load()
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts, exists := c.counts[point]
+	if !exists {
+		counts = make([]int, len(point.branches))
+		c.counts[point] = counts
+	}
+
+	counts[branch]++
+}
+
+// Points - instrumented combinators with counts of branches, sorted by location.
+// Combinators made by process are included even if they are not used.
+func (c *Coverage) Points() []CoveragePoint {
+	registry.mu.Lock()
+	points := make([]*coveragePoint, len(registry.points))
+	copy(points, registry.points)
+	registry.mu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]CoveragePoint, 0, len(points))
+
+	for _, point := range points {
+		branches := make([]CoverageBranch, len(point.branches))
+		for i, label := range point.branches {
+			branches[i].Label = label
+		}
+
+		for i, count := range c.counts[point] {
+			branches[i].Count = count
+		}
+
+		result = append(result, CoveragePoint{
+			Kind:     point.kind,
+			Location: point.location,
+			Name:     point.name,
+			Branches: branches,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return lessLocation(result[i].Location, result[j].Location)
+	})
+
+	return result
+}
+
+func lessLocation(a, b string) bool {
+	fileA, lineA := splitLocation(a)
+	fileB, lineB := splitLocation(b)
+
+	if fileA != fileB {
+		return fileA < fileB
+	}
+
+	return lineA < lineB
+}
+
+func splitLocation(location string) (string, int) {
+	i := strings.LastIndexByte(location, ':')
+	if i < 0 {
+		return location, 0
+	}
+
+	line := 0
+	_, _ = fmt.Sscanf(location[i+1:], "%d", &line)
+
+	return location[:i], line
+}
+
+// Percent - percent of used branches.
+func (c *Coverage) Percent() float64 {
+	return coveragePercent(c.Points())
+}
+
+func coveragePercent(points []CoveragePoint) float64 {
+	total, covered := 0, 0
+
+	for _, point := range points {
+		total += len(point.Branches)
+		covered += point.Covered()
+	}
+
+	if total == 0 {
+		return 100
+	}
+
+	return float64(covered) * 100 / float64(total)
+}
+
+// WriteText - write coverage of combinators to w, one line per combinator
+// with list of not used branches.
+func (c *Coverage) WriteText(w io.Writer) error {
+	points := c.Points()
+
+	for _, point := range points {
+		_, err := fmt.Fprintf(
+			w,
+			"%s: %s %q %d/%d\n",
+			point.Location,
+			point.Kind,
+			point.Name,
+			point.Covered(),
+			len(point.Branches),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, branch := range point.Branches {
+			if branch.Count > 0 {
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "\tnot covered: %s\n", branch.Label); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "coverage: %.1f%% of branches\n", coveragePercent(points))
+	return err
+}
+
+var coverageHTML = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Grammar coverage</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 2px 8px; text-align: left; }
+.covered { background: #dfd; }
+.uncovered { background: #fdd; }
+</style>
+</head>
+<body>
+<h1>Grammar coverage: {{ printf "%.1f" .Percent }}% of branches</h1>
+<table>
+<tr><th>Location</th><th>Kind</th><th>Name</th><th>Branch</th><th>Count</th></tr>
+{{- range .Points }}{{ $point := . }}
+{{- range .Branches }}
+<tr class="{{ if .Count }}covered{{ else }}uncovered{{ end }}"><td>{{ $point.Location }}</td><td>{{ $point.Kind }}</td><td>{{ $point.Name }}</td><td>{{ .Label }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML - write coverage of combinators to w as HTML page,
+// not used branches are highlighted.
+func (c *Coverage) WriteHTML(w io.Writer) error {
+	points := c.Points()
+
+	return coverageHTML.Execute(w, struct {
+		Percent float64
+		Points  []CoveragePoint
+	}{
+		Percent: coveragePercent(points),
+		Points:  points,
+	})
+}
+
+// alternativeLabels - labels of branches of Choice combinator.
+func alternativeLabels(count int) []string {
+	result := make([]string, count)
+
+	for i := range result {
+		result[i] = fmt.Sprintf("alternative %d", i+1)
+	}
+
+	return result
+}
+
+// newMapCoveragePoint - register combinator with branch for each key of cases,
+// returns index of branch for each key (see newCoveragePoint).
+func newMapCoveragePoint[K comparable, V any](name string, cases map[K]V) (*coveragePoint, map[K]int) {
+	var branches map[K]int
+
+	point := newCoveragePoint("map", name, func() []string {
+		keys := make([]K, 0, len(cases))
+		for key := range cases {
+			keys = append(keys, key)
+		}
+
+		var labels []string
+		labels, branches = keyLabels(keys)
+
+		return labels
+	})
+
+	return point, branches
+}
+
+// keyLabels - sorted labels of map keys and index of branch for each key.
+func keyLabels[K comparable](keys []K) ([]string, map[K]int) {
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = fmt.Sprintf("%v", key)
+	}
+
+	sort.Sort(byLabel[K]{labels: labels, keys: keys})
+
+	index := make(map[K]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	return labels, index
+}
+
+type byLabel[K any] struct {
+	labels []string
+	keys   []K
+}
+
+func (x byLabel[K]) Len() int           { return len(x.labels) }
+func (x byLabel[K]) Less(i, j int) bool { return x.labels[i] < x.labels[j] }
+func (x byLabel[K]) Swap(i, j int) {
+	x.labels[i], x.labels[j] = x.labels[j], x.labels[i]
+	x.keys[i], x.keys[j] = x.keys[j], x.keys[i]
+}
@@ -0,0 +1,269 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrDebugStopped - error of parsing stopped by Debugger.
+var ErrDebugStopped = errors.New("debugging stopped")
+
+// Debugger - sink of tracing events which pauses parsing on events of named combinators,
+// so parsing can be executed step by step (see Debug and Named).
+// Breakpoints pause parsing on calls of named combinators with name or at position.
+// Positions of breakpoints are indexes of items, like in Profiler.
+// Methods of debugger are not safe for concurrent use.
+type Debugger struct {
+	rules   map[string]struct{}
+	indexes map[int]struct{}
+
+	events chan Event
+	resume chan bool
+	done   chan struct{}
+
+	started bool
+	paused  bool
+	current Event
+	stack   []Event
+	result  any
+	err     error
+}
+
+var _ Sink = new(Debugger)
+
+// debugStop - panic of stopped parsing.
+type debugStop struct{}
+
+// NewDebugger - make debugger without breakpoints.
+func NewDebugger() *Debugger {
+	return &Debugger{
+		rules:   make(map[string]struct{}),
+		indexes: make(map[int]struct{}),
+		events:  make(chan Event),
+		resume:  make(chan bool),
+		done:    make(chan struct{}),
+	}
+}
+
+// Debug - start parsing of buffer by c combinator under control of d debugger.
+// Parsing is paused before the first event, call Step, Next, Out or Continue to resume it.
+// Only events of this parsing are sent to debugger, other parsers are not affected.
+// Parsing is executed in separate goroutine, so it must be finished by stepping
+// or stopped by Stop, otherwise goroutine is leaked.
+func Debug[T any, P any, S any](d *Debugger, c Combinator[T, P, S], buffer Buffer[T, P]) {
+	if d.started {
+		panic("debugger is already started")
+	}
+
+	d.started = true
+
+	traced := &tracedBuffer[T, P]{buffer: buffer, tracer: newTracer(d)}
+
+	go func() {
+		defer close(d.done)
+
+		defer func() {
+			if x := recover(); x != nil {
+				if _, ok := x.(debugStop); !ok {
+					panic(x)
+				}
+
+				d.result, d.err = nil, ErrDebugStopped
+			}
+		}()
+
+		result, err := c(traced)
+		if err != nil {
+			d.result, d.err = nil, err
+		} else {
+			d.result, d.err = result, nil
+		}
+	}()
+}
+
+// Emit - pause parsing until debugger is resumed.
+func (d *Debugger) Emit(event Event) {
+	d.events <- event
+
+	if stop := <-d.resume; stop {
+		panic(debugStop{})
+	}
+}
+
+// BreakOnRule - pause parsing when named combinator with name is called.
+func (d *Debugger) BreakOnRule(name string) {
+	d.rules[name] = struct{}{}
+}
+
+// BreakAt - pause parsing when named combinator is called at position with index.
+func (d *Debugger) BreakAt(index int) {
+	d.indexes[index] = struct{}{}
+}
+
+// ClearBreakpoints - remove all breakpoints.
+func (d *Debugger) ClearBreakpoints() {
+	clear(d.rules)
+	clear(d.indexes)
+}
+
+// Breakpoints - descriptions of breakpoints, like "rule name" or "index 10".
+func (d *Debugger) Breakpoints() []string {
+	result := make([]string, 0, len(d.rules)+len(d.indexes))
+
+	for name := range d.rules {
+		result = append(result, "rule "+name)
+	}
+
+	for index := range d.indexes {
+		result = append(result, fmt.Sprintf("index %d", index))
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func (d *Debugger) isBreakpoint(event Event) bool {
+	if event.Kind != EventEnter {
+		return false
+	}
+
+	if _, exists := d.rules[event.Name]; exists {
+		return true
+	}
+
+	if index, ok := positionIndex(event.Start); ok {
+		if _, exists := d.indexes[index]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Step - resume parsing until the next event.
+// Returns false if parsing is finished.
+func (d *Debugger) Step() (Event, bool) {
+	return d.advance(func(Event) bool { return true })
+}
+
+// Next - resume parsing until the next event of named combinator
+// at the same or lower depth, nested combinators are skipped.
+// Returns false if parsing is finished.
+func (d *Debugger) Next() (Event, bool) {
+	depth := d.current.Depth
+
+	return d.advance(func(event Event) bool {
+		return event.Depth <= depth
+	})
+}
+
+// Out - resume parsing until exit or backtrack of named combinator
+// which is in progress (the last one in call stack).
+// Returns false if parsing is finished.
+func (d *Debugger) Out() (Event, bool) {
+	depth := len(d.stack) - 1
+
+	return d.advance(func(event Event) bool {
+		return event.Kind != EventEnter && event.Depth <= depth
+	})
+}
+
+// Continue - resume parsing until breakpoint.
+// Returns false if parsing is finished.
+func (d *Debugger) Continue() (Event, bool) {
+	return d.advance(func(Event) bool { return false })
+}
+
+// Stop - stop parsing, result of parsing is ErrDebugStopped error.
+// Must be called if debugging is finished before the end of parsing.
+func (d *Debugger) Stop() {
+	if !d.started {
+		return
+	}
+
+	for {
+		if d.paused {
+			d.paused = false
+			d.resume <- true
+		}
+
+		select {
+		case event := <-d.events:
+			d.current = event
+			d.paused = true
+		case <-d.done:
+			d.stack = nil
+			return
+		}
+	}
+}
+
+func (d *Debugger) advance(stop func(Event) bool) (Event, bool) {
+	if !d.started {
+		return Event{}, false
+	}
+
+	for {
+		if d.paused {
+			d.paused = false
+			d.resume <- false
+		}
+
+		select {
+		case event := <-d.events:
+			d.paused = true
+			d.current = event
+
+			if event.Kind == EventEnter {
+				d.stack = append(d.stack, event)
+			} else if len(d.stack) > 0 {
+				d.stack = d.stack[:len(d.stack)-1]
+			}
+
+			if stop(event) || d.isBreakpoint(event) {
+				return event, true
+			}
+		case <-d.done:
+			d.current = Event{}
+			d.stack = nil
+			return Event{}, false
+		}
+	}
+}
+
+// Current - event on which parsing is paused.
+func (d *Debugger) Current() Event {
+	return d.current
+}
+
+// Stack - enter events of named combinators in progress, from root to the last called one.
+func (d *Debugger) Stack() []Event {
+	result := make([]Event, len(d.stack))
+	copy(result, d.stack)
+	return result
+}
+
+// Done - true if parsing is finished.
+func (d *Debugger) Done() bool {
+	if !d.started {
+		return false
+	}
+
+	select {
+	case <-d.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result - result and error of finished parsing.
+func (d *Debugger) Result() (any, error) {
+	if !d.Done() {
+		return nil, nil
+	}
+
+	return d.result, d.err
+}
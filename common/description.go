@@ -0,0 +1,457 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DescriptionKind - kind of described combinator.
+type DescriptionKind string
+
+const (
+	// KindTerminal - literal items, name is quoted literal.
+	KindTerminal DescriptionKind = "terminal"
+	// KindClass - one item from class of items, name is description of class.
+	KindClass DescriptionKind = "class"
+	// KindSequence - all children one by one.
+	KindSequence DescriptionKind = "sequence"
+	// KindChoice - one of children.
+	KindChoice DescriptionKind = "choice"
+	// KindOptional - optional child.
+	KindOptional DescriptionKind = "optional"
+	// KindMany - zero or more occurrences of child.
+	KindMany DescriptionKind = "many"
+	// KindSome - one or more occurrences of child.
+	KindSome DescriptionKind = "some"
+	// KindSepBy - zero or more occurrences of the first child separated by the second.
+	KindSepBy DescriptionKind = "sepBy"
+	// KindSepBy1 - one or more occurrences of the first child separated by the second.
+	KindSepBy1 DescriptionKind = "sepBy1"
+	// KindRule - named rule (see Named).
+	KindRule DescriptionKind = "rule"
+	// KindRef - reference to named rule (see Ref).
+	KindRef DescriptionKind = "ref"
+	// KindEOF - end of input.
+	KindEOF DescriptionKind = "eof"
+	// KindLookAhead - predicate which succeeds if child matches, doesn't consume input.
+	KindLookAhead DescriptionKind = "lookAhead"
+	// KindNotFollowedBy - predicate which succeeds if child doesn't match, doesn't consume input.
+	KindNotFollowedBy DescriptionKind = "notFollowedBy"
+	// KindUnknown - combinator without description.
+	KindUnknown DescriptionKind = "unknown"
+)
+
+// Description - description of combinator for introspection of grammar.
+type Description struct {
+	// Kind - kind of combinator.
+	Kind DescriptionKind
+	// Name - name of rule, quoted literal of terminal or description of class.
+	Name string
+	// Children - descriptions of nested combinators.
+	Children []*Description
+	// Items - items of terminal as slice of items (see Generator).
+	Items any
+	// Condition - condition of items of class as Condition (see Generator).
+	Condition any
+}
+
+// descriptionsEnabled - combinators carry descriptions (see EnableDescriptions).
+var descriptionsEnabled atomic.Bool
+
+// EnableDescriptions - make combinators to carry descriptions (see DescriptionOf).
+// Only combinators made after the call are described, so call it before building of grammar,
+// for example in init function or TestMain.
+func EnableDescriptions() {
+	descriptionsEnabled.Store(true)
+}
+
+// DisableDescriptions - stop describing of combinators,
+// descriptions of already made combinators are not returned by DescriptionOf.
+func DisableDescriptions() {
+	descriptionsEnabled.Store(false)
+}
+
+// describedCode - code pointers of closures made by describe,
+// only these closures are called by DescriptionOf.
+var describedCode sync.Map
+
+// descriptionProbe - buffer passed to described combinator by DescriptionOf,
+// combinator doesn't parse it and returns own description in it instead.
+type descriptionProbe[T any, P any] struct {
+	description *Description
+}
+
+var _ Buffer[rune, int] = new(descriptionProbe[rune, int])
+
+// Read - return ErrEndOfFile, probe has no items.
+func (*descriptionProbe[T, P]) Read(bool) (T, error) {
+	var null T
+	return null, ErrEndOfFile
+}
+
+// Seek - return ErrOutOfBounds, probe has no items.
+func (*descriptionProbe[T, P]) Seek(P) error {
+	return ErrOutOfBounds
+}
+
+// Position - return zero position.
+func (*descriptionProbe[T, P]) Position() P {
+	var null P
+	return null
+}
+
+// IsEOF - true, probe has no items.
+func (*descriptionProbe[T, P]) IsEOF() bool {
+	return true
+}
+
+// Describe - attach description to c combinator, if descriptions are enabled.
+// Useful for combinators written by hand, combinators without description
+// are never called by DescriptionOf and described as unknown.
+func Describe[T any, P any, S any](c Combinator[T, P, S], d *Description) Combinator[T, P, S] {
+	return describe(c, func() *Description { return d })
+}
+
+// describe - wrap c combinator to carry description made by f, if descriptions are enabled.
+func describe[T any, P any, S any](c Combinator[T, P, S], f func() *Description) Combinator[T, P, S] {
+	var null S
+
+	if c == nil || !descriptionsEnabled.Load() {
+		return c
+	}
+
+	d := f()
+
+	wrapped := func(buffer Buffer[T, P]) (S, Error[P]) {
+		if probe, ok := buffer.(*descriptionProbe[T, P]); ok {
+			probe.description = d
+			return null, nil
+		}
+
+		return c(buffer)
+	}
+
+	describedCode.LoadOrStore(reflect.ValueOf(wrapped).Pointer(), struct{}{})
+
+	return wrapped
+}
+
+// DescriptionOf - description of c combinator,
+// description with unknown kind if combinator isn't described or descriptions are disabled.
+// Only combinators described by library or by Describe are called to get description,
+// combinators without description are not called, so they can't have side effects.
+func DescriptionOf[T any, P any, S any](c Combinator[T, P, S]) *Description {
+	if c != nil && descriptionsEnabled.Load() {
+		if _, exists := describedCode.Load(reflect.ValueOf(c).Pointer()); exists {
+			probe := new(descriptionProbe[T, P])
+			_, _ = c(probe)
+
+			if probe.description != nil {
+				return probe.description
+			}
+		}
+	}
+
+	return &Description{Kind: KindUnknown}
+}
+
+func descriptionsOf[T any, P any, S any](cs ...Combinator[T, P, S]) []*Description {
+	result := make([]*Description, len(cs))
+
+	for i, c := range cs {
+		result[i] = DescriptionOf(c)
+	}
+
+	return result
+}
+
+// Ref - reference to combinator declared later by pointer, useful for recursive grammars.
+// Described as reference to rule with name (see Named).
+func Ref[T any, P any, S any](name string, c *Combinator[T, P, S]) Combinator[T, P, S] {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
+		return (*c)(buffer)
+	}, func() *Description {
+		return &Description{Kind: KindRef, Name: name}
+	})
+}
+
+func terminal[T any](items ...T) *Description {
+	return &Description{Kind: KindTerminal, Name: literal(items), Items: items}
+}
+
+func class[T any](name string, condition Condition[T]) *Description {
+	return &Description{Kind: KindClass, Name: name, Condition: condition}
+}
+
+func nested(kind DescriptionKind, children ...*Description) *Description {
+	return &Description{Kind: kind, Children: children}
+}
+
+// literal - runes as quoted string, bytes as hex, other items as Go values.
+func literal[T any](items []T) string {
+	switch x := any(items).(type) {
+	case []rune:
+		return strconv.Quote(string(x))
+	case []byte:
+		return "0x" + hex.EncodeToString(x)
+	default:
+		strs := make([]string, len(items))
+		for i, item := range items {
+			strs[i] = fmt.Sprintf("%v", item)
+		}
+
+		return strings.Join(strs, " ")
+	}
+}
+
+func literals[T any](items []T) []string {
+	result := make([]string, len(items))
+
+	for i, item := range items {
+		result[i] = literal([]T{item})
+	}
+
+	return result
+}
+
+// Rules - named rules of grammar in order of appearance, starting with d.
+// If d is not a rule, it's wrapped to rule with name "grammar".
+func (d *Description) Rules() []*Description {
+	root := d
+	if root.Kind != KindRule {
+		root = &Description{Kind: KindRule, Name: "grammar", Children: []*Description{d}}
+	}
+
+	result := make([]*Description, 0)
+	visited := make(map[*Description]struct{})
+
+	var visit func(x *Description)
+	visit = func(x *Description) {
+		if _, exists := visited[x]; exists {
+			return
+		}
+		visited[x] = struct{}{}
+
+		if x.Kind == KindRule {
+			result = append(result, x)
+		}
+
+		for _, child := range x.Children {
+			visit(child)
+		}
+	}
+
+	visit(root)
+
+	return result
+}
+
+// String - description in EBNF notation, nested rules are printed as names.
+func (d *Description) String() string {
+	if d.Kind == KindRule && len(d.Children) > 0 {
+		return ebnf(d.Children[0], 0)
+	}
+
+	return ebnf(d, 0)
+}
+
+// WriteEBNF - write rules of grammar to w in EBNF notation (ISO 14977),
+// classes of items, predicates and combinators without descriptions
+// are written as special sequences.
+func (d *Description) WriteEBNF(w io.Writer) error {
+	for _, rule := range d.Rules() {
+		if _, err := fmt.Fprintf(w, "%s = %s ;\n", rule.Name, rule.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const (
+	precedenceChoice = iota + 1
+	precedenceSequence
+)
+
+func ebnf(d *Description, precedence int) string {
+	child := func(i int, p int) string {
+		if i < len(d.Children) {
+			return ebnf(d.Children[i], p)
+		}
+
+		return "? unknown ?"
+	}
+
+	switch d.Kind {
+	case KindTerminal, KindRule, KindRef:
+		return d.Name
+	case KindClass:
+		return "? " + d.Name + " ?"
+	case KindEOF:
+		return "? end of input ?"
+	case KindLookAhead, KindNotFollowedBy:
+		return "? " + predicate(d) + " ?"
+	case KindSequence:
+		if len(d.Children) == 1 {
+			return child(0, precedence)
+		}
+
+		return parens(joinEBNF(d.Children, " , ", precedenceSequence), precedence > precedenceSequence)
+	case KindChoice:
+		if len(d.Children) == 1 {
+			return child(0, precedence)
+		}
+
+		return parens(joinEBNF(d.Children, " | ", precedenceChoice), precedence > precedenceChoice)
+	case KindOptional:
+		return "[ " + child(0, 0) + " ]"
+	case KindMany:
+		return "{ " + child(0, 0) + " }"
+	case KindSome:
+		item := child(0, precedenceSequence)
+		return parens(item+" , { "+child(0, 0)+" }", precedence > precedenceSequence)
+	case KindSepBy:
+		item := child(0, precedenceSequence)
+		return "[ " + item + " , { " + child(1, precedenceSequence) + " , " + item + " } ]"
+	case KindSepBy1:
+		item := child(0, precedenceSequence)
+		return parens(
+			item+" , { "+child(1, precedenceSequence)+" , "+item+" }",
+			precedence > precedenceSequence,
+		)
+	default:
+		return "? unknown ?"
+	}
+}
+
+// predicate - text of lookahead predicate, like "not followed by "a"".
+func predicate(d *Description) string {
+	child := "? unknown ?"
+	if len(d.Children) > 0 {
+		child = ebnf(d.Children[0], 0)
+	}
+
+	if d.Kind == KindNotFollowedBy {
+		return "not followed by " + child
+	}
+
+	return "followed by " + child
+}
+
+func joinEBNF(ds []*Description, separator string, precedence int) string {
+	strs := make([]string, len(ds))
+
+	for i, d := range ds {
+		strs[i] = ebnf(d, precedence+1)
+	}
+
+	return strings.Join(strs, separator)
+}
+
+func parens(s string, ok bool) string {
+	if ok {
+		return "( " + s + " )"
+	}
+
+	return s
+}
+
+// WriteDOT - write graph of grammar to w in Graphviz DOT format,
+// references to rules are drawn as dashed edges.
+func (d *Description) WriteDOT(w io.Writer) error {
+	b := new(strings.Builder)
+	b.WriteString("digraph grammar {\n")
+	b.WriteString("\tnode [fontname=\"monospace\"];\n")
+
+	rules := make(map[string]*Description)
+	for _, rule := range d.Rules() {
+		rules[rule.Name] = rule
+	}
+
+	ids := make(map[*Description]string)
+
+	var visit func(x *Description) string
+	visit = func(x *Description) string {
+		if id, exists := ids[x]; exists {
+			return id
+		}
+
+		id := "n" + strconv.Itoa(len(ids))
+		ids[x] = id
+
+		label, shape := string(x.Kind), "box"
+		switch x.Kind {
+		case KindTerminal:
+			label, shape = x.Name, "ellipse"
+		case KindClass:
+			label, shape = x.Name, "diamond"
+		case KindRule:
+			label, shape = x.Name, "box, style=bold"
+		case KindRef:
+			label, shape = x.Name, "box, style=dashed"
+		case KindLookAhead, KindNotFollowedBy:
+			shape = "box, style=rounded"
+		}
+
+		fmt.Fprintf(b, "\t%s [label=%s, shape=%s];\n", id, strconv.Quote(label), shape)
+
+		for _, child := range x.Children {
+			fmt.Fprintf(b, "\t%s -> %s;\n", id, visit(child))
+		}
+
+		if rule, exists := rules[x.Name]; exists && x.Kind == KindRef {
+			fmt.Fprintf(b, "\t%s -> %s [style=dashed];\n", id, visit(rule))
+		}
+
+		return id
+	}
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	visit(d)
+	for _, name := range names {
+		visit(rules[name])
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func oneOf[T any](items []T) *Description {
+	if len(items) == 1 {
+		return terminal(items...)
+	}
+
+	children := make([]*Description, len(items))
+	for i, item := range items {
+		children[i] = terminal(item)
+	}
+
+	return nested(KindChoice, children...)
+}
+
+func mapTreeDescription[T comparable, K any, C any](cases map[T]C, split func(T) []K) *Description {
+	children := make([]*Description, 0, len(cases))
+	for key := range cases {
+		children = append(children, terminal(split(key)...))
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Name < children[j].Name
+	})
+
+	return nested(KindChoice, children...)
+}
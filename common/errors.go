@@ -8,6 +8,7 @@ import (
 var (
 	ErrEndOfFile   = errors.New("end of file")
 	ErrOutOfBounds = errors.New("out of bounds")
+	ErrOverflow    = errors.New("integer overflow")
 )
 
 type Error[T any] interface {
@@ -28,6 +29,11 @@ func (err ParseError[T]) Error() string {
 	return fmt.Sprintf("Parse error at %v: %s", err.position, err.message)
 }
 
+// Message - message of error without position.
+func (err ParseError[T]) Message() string {
+	return err.message
+}
+
 func (err ParseError[T]) Position() T {
 	return err.position
 }
@@ -0,0 +1,335 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Generator - generator of random inputs by description of grammar (see DescriptionOf)
+// for property-based tests and corpora of fuzz tests.
+// It implements Arbitrary interface of oh-snap package.
+// Items of classes are taken from alphabet, items of terminals must have type T.
+// Generated inputs are valid if ordered choices and greedy repetitions
+// of grammar are not ambiguous, like in most of grammars of data formats.
+// Predicates (LookAhead and NotFollowedBy) don't consume input, so nothing is generated
+// for them and inputs are valid only if items generated after predicate satisfy it.
+type Generator[T any] struct {
+	// MaxDepth - depth of nested rules, after which the shortest alternatives
+	// and the least count of repetitions are chosen to finish recursion.
+	MaxDepth int
+	// MaxRepeat - max count of repetitions of Many, Some, SepBy and similar combinators.
+	MaxRepeat int
+
+	rand     *rand.Rand
+	root     *Description
+	alphabet []T
+	rules    map[string]*Description
+	// costs - count of items of the shortest input for each description
+	costs map[*Description]int
+	// candidates - items of alphabet matched by class
+	candidates map[*Description][]T
+}
+
+// unreachable - cost of description which can't be generated
+const unreachable = math.MaxInt
+
+// NewGenerator - make generator of inputs by d description with MaxDepth 8 and MaxRepeat 4.
+// Returns error if valid input can't be generated, for example if grammar contains
+// combinators without description or classes without items in alphabet.
+func NewGenerator[T any](rnd *rand.Rand, d *Description, alphabet []T) (*Generator[T], error) {
+	g := &Generator[T]{
+		MaxDepth:   8,
+		MaxRepeat:  4,
+		rand:       rnd,
+		root:       d,
+		alphabet:   alphabet,
+		rules:      make(map[string]*Description),
+		costs:      make(map[*Description]int),
+		candidates: make(map[*Description][]T),
+	}
+
+	for _, rule := range d.Rules() {
+		g.rules[rule.Name] = rule
+	}
+
+	nodes := make([]*Description, 0)
+	visited := make(map[*Description]struct{})
+
+	var visit func(x *Description)
+	visit = func(x *Description) {
+		if _, exists := visited[x]; exists {
+			return
+		}
+
+		visited[x] = struct{}{}
+		nodes = append(nodes, x)
+
+		if x.Kind == KindClass {
+			if condition, ok := x.Condition.(Condition[T]); ok {
+				for _, item := range alphabet {
+					if condition(item) {
+						g.candidates[x] = append(g.candidates[x], item)
+					}
+				}
+			}
+		}
+
+		for _, child := range x.Children {
+			visit(child)
+		}
+	}
+
+	visit(d)
+
+	for _, x := range nodes {
+		g.costs[x] = unreachable
+	}
+
+	// costs of recursive rules depend on each other,
+	// so they are calculated until nothing changed
+	for changed := true; changed; {
+		changed = false
+
+		for _, x := range nodes {
+			if cost := g.cost(x); cost < g.costs[x] {
+				g.costs[x] = cost
+				changed = true
+			}
+		}
+	}
+
+	if g.costs[d] == unreachable {
+		return nil, fmt.Errorf("input can't be generated by grammar %s", d)
+	}
+
+	return g, nil
+}
+
+// cost - count of items of the shortest input by costs of children.
+func (g *Generator[T]) cost(d *Description) int {
+	child := func(i int) int {
+		if i < len(d.Children) {
+			return g.costs[d.Children[i]]
+		}
+
+		return unreachable
+	}
+
+	switch d.Kind {
+	case KindTerminal:
+		if items, ok := d.Items.([]T); ok {
+			return len(items)
+		}
+
+		return unreachable
+	case KindClass:
+		if len(g.candidates[d]) > 0 {
+			return 1
+		}
+
+		return unreachable
+	case KindEOF, KindLookAhead, KindNotFollowedBy, KindOptional, KindMany, KindSepBy:
+		return 0
+	case KindSequence:
+		total := 0
+
+		for i := range d.Children {
+			cost := child(i)
+			if cost == unreachable {
+				return unreachable
+			}
+
+			total += cost
+		}
+
+		return total
+	case KindChoice:
+		result := unreachable
+
+		for i := range d.Children {
+			result = min(result, child(i))
+		}
+
+		return result
+	case KindSome, KindSepBy1, KindRule:
+		return child(0)
+	case KindRef:
+		if rule, exists := g.rules[d.Name]; exists {
+			return g.costs[rule]
+		}
+
+		return unreachable
+	default:
+		return unreachable
+	}
+}
+
+// Generate - generate random valid input.
+func (g *Generator[T]) Generate() []T {
+	return g.generate(make([]T, 0), g.root, 0)
+}
+
+// Shrink - generated inputs are not shrunk, because parts of valid input are not valid usually.
+func (g *Generator[T]) Shrink(_ []T) [][]T {
+	return nil
+}
+
+func (g *Generator[T]) generate(out []T, d *Description, depth int) []T {
+	switch d.Kind {
+	case KindTerminal:
+		items, _ := d.Items.([]T)
+		return append(out, items...)
+	case KindClass:
+		candidates := g.candidates[d]
+		return append(out, candidates[g.rand.IntN(len(candidates))])
+	case KindSequence:
+		for _, x := range d.Children {
+			out = g.generate(out, x, depth)
+		}
+
+		return out
+	case KindChoice:
+		return g.generate(out, g.alternative(d, depth), depth)
+	case KindOptional:
+		if g.repeat(d.Children[0], 0, 1, depth) > 0 {
+			out = g.generate(out, d.Children[0], depth)
+		}
+
+		return out
+	case KindMany, KindSome:
+		from := 0
+		if d.Kind == KindSome {
+			from = 1
+		}
+
+		for i := g.repeat(d.Children[0], from, g.MaxRepeat, depth); i > 0; i-- {
+			out = g.generate(out, d.Children[0], depth)
+		}
+
+		return out
+	case KindSepBy, KindSepBy1:
+		from := 0
+		if d.Kind == KindSepBy1 {
+			from = 1
+		}
+
+		to := g.MaxRepeat
+		if g.costs[d.Children[1]] == unreachable {
+			to = min(to, 1)
+		}
+
+		n := g.repeat(d.Children[0], from, to, depth)
+
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				out = g.generate(out, d.Children[1], depth)
+			}
+
+			out = g.generate(out, d.Children[0], depth)
+		}
+
+		return out
+	case KindRule:
+		return g.generate(out, d.Children[0], depth+1)
+	case KindRef:
+		return g.generate(out, g.rules[d.Name], depth)
+	case KindLookAhead, KindNotFollowedBy:
+		// predicates don't consume input
+		return out
+	default:
+		return out
+	}
+}
+
+// alternative - random alternative of choice which can be generated,
+// the shortest one if depth is exceeded.
+func (g *Generator[T]) alternative(d *Description, depth int) *Description {
+	limit := unreachable - 1
+	if depth >= g.MaxDepth {
+		limit = g.costs[d]
+	}
+
+	alternatives := make([]*Description, 0, len(d.Children))
+	for _, x := range d.Children {
+		if g.costs[x] <= limit {
+			alternatives = append(alternatives, x)
+		}
+	}
+
+	return alternatives[g.rand.IntN(len(alternatives))]
+}
+
+// repeat - random count of repetitions of d description from range,
+// the least one if depth is exceeded or d can't be generated.
+func (g *Generator[T]) repeat(d *Description, from, to int, depth int) int {
+	if depth >= g.MaxDepth || g.costs[d] == unreachable || to <= from {
+		return from
+	}
+
+	return from + g.rand.IntN(to-from+1)
+}
+
+// GenerateInvalid - generate random valid input and mutate it (see Mutate),
+// so it's invalid usually.
+func (g *Generator[T]) GenerateInvalid() []T {
+	return g.Mutate(g.Generate())
+}
+
+// Mutate - copy of input with one to three random mutations:
+// deletion, insertion or replacement of item by item of alphabet,
+// duplication of part of input.
+func (g *Generator[T]) Mutate(input []T) []T {
+	result := make([]T, len(input), len(input)+1)
+	copy(result, input)
+
+	for i := 1 + g.rand.IntN(3); i > 0; i-- {
+		kind := g.rand.IntN(4)
+		if len(result) == 0 {
+			kind = 1
+		}
+
+		switch kind {
+		case 0:
+			j := g.rand.IntN(len(result))
+			result = append(result[:j], result[j+1:]...)
+		case 1:
+			j := g.rand.IntN(len(result) + 1)
+			result = append(result[:j], append([]T{g.randomItem()}, result[j:]...)...)
+		case 2:
+			result[g.rand.IntN(len(result))] = g.randomItem()
+		case 3:
+			from := g.rand.IntN(len(result))
+			to := from + 1 + g.rand.IntN(len(result)-from)
+			part := append([]T{}, result[from:to]...)
+			result = append(result[:to], append(part, result[to:]...)...)
+		}
+	}
+
+	return result
+}
+
+func (g *Generator[T]) randomItem() T {
+	if len(g.alphabet) == 0 {
+		var null T
+		return null
+	}
+
+	return g.alphabet[g.rand.IntN(len(g.alphabet))]
+}
+
+// Corpus - n random inputs for seed corpus of fuzz test,
+// every second input is mutated.
+func (g *Generator[T]) Corpus(n int) [][]T {
+	result := make([][]T, n)
+
+	for i := range result {
+		if i%2 == 0 {
+			result[i] = g.Generate()
+		} else {
+			result[i] = g.GenerateInvalid()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+type isolatedBuffer[T any, P comparable] struct {
+	buffer Buffer[T, P]
+	limit  int
+	// positions[i] - position of buffer after reading of i items
+	positions []P
+}
+
+var _ Buffer[rune, int] = new(isolatedBuffer[rune, int])
+
+func newIsolatedBuffer[T any, P comparable](
+	buffer Buffer[T, P],
+	limit int,
+) *isolatedBuffer[T, P] {
+	b := new(isolatedBuffer[T, P])
+	b.buffer = buffer
+	b.limit = limit
+	b.positions = []P{buffer.Position()}
+	return b
+}
+
+// Read - read next item, if greedy buffer keep position after reading.
+// Returns ErrEndOfFile if limit of items is reached.
+func (b *isolatedBuffer[T, P]) Read(greedy bool) (T, error) {
+	if b.consumed() >= b.limit {
+		var null T
+		return null, ErrEndOfFile
+	}
+
+	x, err := b.buffer.Read(greedy)
+	if err != nil {
+		return x, err
+	}
+
+	if greedy {
+		b.positions = append(b.positions, b.buffer.Position())
+	}
+
+	return x, nil
+}
+
+// Seek - change buffer position,
+// only already readed positions of isolated part are allowed.
+func (b *isolatedBuffer[T, P]) Seek(position P) error {
+	for i := len(b.positions) - 1; i >= 0; i-- {
+		if b.positions[i] != position {
+			continue
+		}
+
+		if err := b.buffer.Seek(position); err != nil {
+			return err
+		}
+
+		b.positions = b.positions[:i+1]
+		return nil
+	}
+
+	return ErrOutOfBounds
+}
+
+// Position - return current buffer position
+func (b *isolatedBuffer[T, P]) Position() P {
+	return b.buffer.Position()
+}
+
+// IsEOF - true if buffer ended or limit of items is reached.
+func (b *isolatedBuffer[T, P]) IsEOF() bool {
+	return b.consumed() >= b.limit || b.buffer.IsEOF()
+}
+
+// Peek - return up to n next items of isolated part without reading them.
+func (b *isolatedBuffer[T, P]) Peek(n int) []T {
+	return peek(b.buffer, min(n, b.limit-b.consumed()))
+}
+
+// Unwrap - return wrapped buffer.
+func (b *isolatedBuffer[T, P]) Unwrap() Buffer[T, P] {
+	return b.buffer
+}
+
+func (b *isolatedBuffer[T, P]) consumed() int {
+	return len(b.positions) - 1
+}
+
+// Isolate - restrict c combinator to exactly n items of the buffer.
+// Combinator c can't read more than n items,
+// returns ParseError if it fails or doesn't consume all n items.
+func Isolate[T any, P comparable, S any](
+	errMessage string,
+	n int,
+	c Combinator[T, P, S],
+) Combinator[T, P, S] {
+	var null S
+
+	return func(buffer Buffer[T, P]) (S, Error[P]) {
+		pos := buffer.Position()
+
+		if n < 0 {
+			return null, NewParseError(
+				pos,
+				errMessage,
+				NewParseError(pos, fmt.Sprintf("invalid count of items: %d", n)),
+			)
+		}
+
+		isolated := newIsolatedBuffer(buffer, n)
+
+		result, err := c(isolated)
+		if err != nil {
+			return null, NewParseError(pos, errMessage, err)
+		}
+
+		if rest := n - isolated.consumed(); rest > 0 {
+			return null, NewParseError(
+				pos,
+				errMessage,
+				NewParseError(
+					buffer.Position(),
+					fmt.Sprintf("expected %d items to be consumed, actual %d", n, n-rest),
+				),
+			)
+		}
+
+		return result, nil
+	}
+}
+
+// LengthPrefixed - read length of data by length combinator,
+// then parse exactly that count of items by body combinator (see Isolate).
+// Useful for TLV-style formats.
+func LengthPrefixed[T any, P comparable, L constraints.Integer, S any](
+	errMessage string,
+	length Combinator[T, P, L],
+	body Combinator[T, P, S],
+) Combinator[T, P, S] {
+	var null S
+
+	return func(buffer Buffer[T, P]) (S, Error[P]) {
+		n, err := length(buffer)
+		if err != nil {
+			return null, err
+		}
+
+		return Isolate(errMessage, int(n), body)(buffer)
+	}
+}
@@ -0,0 +1,109 @@
+package common
+
+// Span - start and end positions of parsed input.
+type Span[P any] struct {
+	// Start - position of buffer before parsing.
+	Start P
+	// End - position of buffer after parsing.
+	End P
+}
+
+// Located - result of combinator with span of parsed input (see WithSpan).
+type Located[S any, P any] struct {
+	Value S
+	Span  Span[P]
+}
+
+// Position - span of parsed input.
+func (l Located[S, P]) Position() Span[P] {
+	return l.Span
+}
+
+// Relocate - copy of result with span changed by move function.
+func (l Located[S, P]) Relocate(move func(P) P) Located[S, P] {
+	l.Span.Start = move(l.Span.Start)
+	l.Span.End = move(l.Span.End)
+	return l
+}
+
+// Locations - results of WithSpan combinators, for example parsed by Sequence.
+type Locations[S any, P any] []Located[S, P]
+
+// Position - span from the start of the first result to the end of the last result.
+// Returns zero span for empty slice.
+func (l Locations[S, P]) Position() Span[P] {
+	if len(l) == 0 {
+		return Span[P]{}
+	}
+
+	return Span[P]{
+		Start: l[0].Span.Start,
+		End:   l[len(l)-1].Span.End,
+	}
+}
+
+// Relocate - copy of results with spans changed by move function.
+func (l Locations[S, P]) Relocate(move func(P) P) Locations[S, P] {
+	result := make(Locations[S, P], len(l))
+
+	for i, x := range l {
+		result[i] = x.Relocate(move)
+	}
+
+	return result
+}
+
+// Values - results without spans.
+func (l Locations[S, P]) Values() []S {
+	result := make([]S, len(l))
+
+	for i, x := range l {
+		result[i] = x.Value
+	}
+
+	return result
+}
+
+// WithSpan - wrap result of c combinator with positions of buffer
+// before and after parsing.
+func WithSpan[T any, P any, S any](c Combinator[T, P, S]) Combinator[T, P, Located[S, P]] {
+	return func(buffer Buffer[T, P]) (Located[S, P], Error[P]) {
+		start := buffer.Position()
+
+		result, err := c(buffer)
+		if err != nil {
+			return Located[S, P]{}, err
+		}
+
+		return Located[S, P]{
+			Value: result,
+			Span: Span[P]{
+				Start: start,
+				End:   buffer.Position(),
+			},
+		}, nil
+	}
+}
+
+// SequenceWithSpan - like Sequence, but wrap results of each combinator
+// with positions, span of all results is returned by Position method.
+func SequenceWithSpan[T any, P any, S any](
+	cap int,
+	cs ...Combinator[T, P, S],
+) Combinator[T, P, Locations[S, P]] {
+	located := make([]Combinator[T, P, Located[S, P]], len(cs))
+	for i, c := range cs {
+		located[i] = WithSpan(c)
+	}
+
+	seq := Sequence(cap, located...)
+
+	return func(buffer Buffer[T, P]) (Locations[S, P], Error[P]) {
+		result, err := seq(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		return Locations[S, P](result), nil
+	}
+}
@@ -8,7 +8,7 @@ func Or[T any, P any, S any](
 ) Combinator[T, P, S] {
 	var null S
 
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		pos := buffer.Position()
 
 		result, xErr := x(buffer)
@@ -22,7 +22,9 @@ func Or[T any, P any, S any](
 		}
 
 		return null, NewParseError(pos, errMessage, xErr, yErr)
-	}
+	}, func() *Description {
+		return nested(KindChoice, DescriptionOf(x), DescriptionOf(y))
+	})
 }
 
 // And - use x and y combinators to consume input data.
@@ -34,7 +36,7 @@ func And[T any, P any, S any, B any, M any](
 ) Combinator[T, P, M] {
 	var null M
 
-	return func(buffer Buffer[T, P]) (M, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) (M, Error[P]) {
 		first, xErr := x(buffer)
 		if xErr != nil {
 			return null, xErr
@@ -46,5 +48,7 @@ func And[T any, P any, S any, B any, M any](
 		}
 
 		return compose(first, second), nil
-	}
+	}, func() *Description {
+		return nested(KindSequence, DescriptionOf(x), DescriptionOf(y))
+	})
 }
@@ -0,0 +1,354 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SyntaxNode - node of concrete syntax tree, produced by Node combinator.
+type SyntaxNode[T any, P any] struct {
+	// Name - name of node passed to Node combinator.
+	Name string
+	// Start - position of buffer before parsing.
+	Start P
+	// End - position of buffer after parsing.
+	End P
+	// Items - matched items of buffer (runes, bytes or tokens).
+	Items []T
+	// Value - result of wrapped combinator.
+	Value any
+	// Children - nodes produced by nested Node combinators.
+	Children []*SyntaxNode[T, P]
+	// Leading - trivia before items of token node (see Lossless).
+	Leading []T
+	// Trailing - trivia after items of token node (see Lossless).
+	Trailing []T
+
+	// offset - count of items read by parent before the node
+	offset int
+	// size - count of items read by node, including trivia
+	size int
+}
+
+// Visitor - visitor of syntax tree nodes (see Visit).
+type Visitor[T any, P any] interface {
+	// Enter - called before visiting of children,
+	// if it returns false children are skipped.
+	Enter(node *SyntaxNode[T, P]) bool
+	// Leave - called after visiting of children.
+	Leave(node *SyntaxNode[T, P])
+}
+
+// treeBuffer - buffer which collects matched items and
+// nodes produced by nested Node combinators.
+type treeBuffer[T any, P comparable] struct {
+	buffer Buffer[T, P]
+	// positions[i] - position of buffer after reading of i items
+	positions []P
+	items     []T
+	children  []*SyntaxNode[T, P]
+	// ends[i] - count of items read before the end of children[i]
+	ends []int
+	// lossless - track items skipped as trivia
+	lossless bool
+	trivia   []span
+}
+
+var _ Buffer[rune, int] = new(treeBuffer[rune, int])
+
+// Read - read next item, if greedy buffer keep position after reading.
+func (b *treeBuffer[T, P]) Read(greedy bool) (T, error) {
+	x, err := b.buffer.Read(greedy)
+	if err != nil {
+		return x, err
+	}
+
+	if greedy {
+		b.positions = append(b.positions, b.buffer.Position())
+		b.items = append(b.items, x)
+	}
+
+	return x, nil
+}
+
+// Seek - change buffer position, only already readed positions are allowed.
+// Nodes which ended after the new position are discarded.
+func (b *treeBuffer[T, P]) Seek(position P) error {
+	for i := len(b.positions) - 1; i >= 0; i-- {
+		if b.positions[i] != position {
+			continue
+		}
+
+		if err := b.buffer.Seek(position); err != nil {
+			return err
+		}
+
+		b.positions = b.positions[:i+1]
+		b.items = b.items[:i]
+
+		for len(b.ends) > 0 && b.ends[len(b.ends)-1] > i {
+			b.ends = b.ends[:len(b.ends)-1]
+			b.children = b.children[:len(b.children)-1]
+		}
+
+		trivia := b.trivia[:0]
+		for _, x := range b.trivia {
+			if x.from < i {
+				trivia = append(trivia, span{from: x.from, to: min(x.to, i)})
+			}
+		}
+		b.trivia = trivia
+
+		return nil
+	}
+
+	return ErrOutOfBounds
+}
+
+// Position - return current buffer position
+func (b *treeBuffer[T, P]) Position() P {
+	return b.buffer.Position()
+}
+
+// IsEOF - true if buffer ended.
+func (b *treeBuffer[T, P]) IsEOF() bool {
+	return b.buffer.IsEOF()
+}
+
+// Peek - return up to n next items without reading them.
+func (b *treeBuffer[T, P]) Peek(n int) []T {
+	return peek(b.buffer, n)
+}
+
+// Unwrap - return wrapped buffer.
+func (b *treeBuffer[T, P]) Unwrap() Buffer[T, P] {
+	return b.buffer
+}
+
+// treeOf - tree buffer of parent node, buffer itself or buffer wrapped by it.
+func treeOf[T any, P comparable](buffer Buffer[T, P]) (*treeBuffer[T, P], bool) {
+	for {
+		if b, ok := buffer.(*treeBuffer[T, P]); ok {
+			return b, true
+		}
+
+		w, ok := buffer.(Wrapper[T, P])
+		if !ok {
+			return nil, false
+		}
+
+		buffer = w.Unwrap()
+	}
+}
+
+func (b *treeBuffer[T, P]) add(node *SyntaxNode[T, P]) {
+	node.offset = len(b.items) - node.size
+	b.children = append(b.children, node)
+	b.ends = append(b.ends, len(b.items))
+}
+
+func (b *treeBuffer[T, P]) consumed() int {
+	return len(b.items)
+}
+
+func (b *treeBuffer[T, P]) markTrivia(from, to int) {
+	if !b.lossless || from >= to {
+		return
+	}
+
+	b.trivia = append(b.trivia, span{from: from, to: to})
+
+	if parent, ok := triviaOf(b.buffer); ok {
+		shift := parent.consumed() - b.consumed()
+		parent.markTrivia(from+shift, to+shift)
+	}
+}
+
+// Node - wrap c combinator to produce node of syntax tree with name,
+// matched items, positions and result of c combinator as value.
+// Nodes produced by Node combinators inside c become children of the node,
+// including nodes produced inside wrapped buffers (for example, by Isolate).
+func Node[T any, P comparable, S any](
+	name string,
+	c Combinator[T, P, S],
+) Combinator[T, P, *SyntaxNode[T, P]] {
+	return func(buffer Buffer[T, P]) (*SyntaxNode[T, P], Error[P]) {
+		parent, nested := treeOf(buffer)
+		node, _, err := parseNode(name, c, buffer, nested && parent.lossless)
+		return node, err
+	}
+}
+
+func parseNode[T any, P comparable, S any](
+	name string,
+	c Combinator[T, P, S],
+	buffer Buffer[T, P],
+	lossless bool,
+) (*SyntaxNode[T, P], *treeBuffer[T, P], Error[P]) {
+	start := buffer.Position()
+
+	tree := &treeBuffer[T, P]{
+		buffer:    buffer,
+		positions: []P{start},
+		lossless:  lossless,
+	}
+
+	value, err := c(tree)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	node := &SyntaxNode[T, P]{
+		Name:     name,
+		Start:    start,
+		End:      buffer.Position(),
+		Items:    tree.items,
+		Value:    value,
+		Children: tree.children,
+		size:     len(tree.items),
+	}
+
+	if parent, ok := treeOf(buffer); ok {
+		parent.add(node)
+	}
+
+	return node, tree, nil
+}
+
+// Source - items of token nodes of subtree with their trivia.
+// For trees produced by Lossless it's equal to the matched input,
+// changed items and children of nodes are reflected in result.
+func (n *SyntaxNode[T, P]) Source() []T {
+	return n.appendSource(make([]T, 0, n.size))
+}
+
+func (n *SyntaxNode[T, P]) appendSource(dst []T) []T {
+	dst = append(dst, n.Leading...)
+
+	if len(n.Children) == 0 {
+		dst = append(dst, n.Items...)
+	}
+
+	for _, child := range n.Children {
+		dst = child.appendSource(dst)
+	}
+
+	return append(dst, n.Trailing...)
+}
+
+// Relocate - copy of subtree with positions changed by move function,
+// items and values are shared with original nodes.
+func (n *SyntaxNode[T, P]) Relocate(move func(P) P) *SyntaxNode[T, P] {
+	node := *n
+	node.Start = move(n.Start)
+	node.End = move(n.End)
+
+	if n.Children != nil {
+		node.Children = make([]*SyntaxNode[T, P], len(n.Children))
+		for i, child := range n.Children {
+			node.Children[i] = child.Relocate(move)
+		}
+	}
+
+	return &node
+}
+
+// Child - first child with name, nil if not found.
+func (n *SyntaxNode[T, P]) Child(name string) *SyntaxNode[T, P] {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// Find - all nodes of subtree with name in depth-first order, including node itself.
+func (n *SyntaxNode[T, P]) Find(name string) []*SyntaxNode[T, P] {
+	result := make([]*SyntaxNode[T, P], 0)
+
+	n.Walk(func(node *SyntaxNode[T, P], _ int) bool {
+		if node.Name == name {
+			result = append(result, node)
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// Walk - call visit for each node of subtree in depth-first order
+// with depth of node relative to n. If visit returns false children of node are skipped.
+func (n *SyntaxNode[T, P]) Walk(visit func(node *SyntaxNode[T, P], depth int) bool) {
+	n.walk(visit, 0)
+}
+
+func (n *SyntaxNode[T, P]) walk(visit func(node *SyntaxNode[T, P], depth int) bool, depth int) {
+	if !visit(n, depth) {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.walk(visit, depth+1)
+	}
+}
+
+// Visit - traverse subtree of node by visitor in depth-first order.
+func Visit[T any, P any](node *SyntaxNode[T, P], v Visitor[T, P]) {
+	if v.Enter(node) {
+		for _, child := range node.Children {
+			Visit(child, v)
+		}
+	}
+
+	v.Leave(node)
+}
+
+// String - return indented representation of subtree, one node per line.
+func (n *SyntaxNode[T, P]) String() string {
+	b := new(strings.Builder)
+	_ = Fprint(b, n)
+	return b.String()
+}
+
+// Fprint - write indented representation of subtree to w, one node per line:
+// name, matched items and positions. Runes are printed as quoted string,
+// bytes as hex string, other items as Go values.
+func Fprint[T any, P any](w io.Writer, node *SyntaxNode[T, P]) error {
+	var err error
+
+	node.Walk(func(x *SyntaxNode[T, P], depth int) bool {
+		if err != nil {
+			return false
+		}
+
+		_, err = fmt.Fprintf(
+			w,
+			"%s%s %s [%v - %v]\n",
+			strings.Repeat("  ", depth),
+			x.Name,
+			formatItems(x.Items),
+			x.Start,
+			x.End,
+		)
+
+		return err == nil
+	})
+
+	return err
+}
+
+func formatItems[T any](items []T) string {
+	switch x := any(items).(type) {
+	case []rune:
+		return strconv.Quote(string(x))
+	case []byte:
+		return fmt.Sprintf("%x", x)
+	default:
+		return fmt.Sprintf("%v", items)
+	}
+}
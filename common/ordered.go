@@ -11,8 +11,12 @@ func Range[T constraints.Ordered, P any](
 	errMessage string,
 	from, to T,
 ) Combinator[T, P, T] {
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	f := func(x T) bool {
 		return x >= from && x <= to
+	}
+
+	return describe(Satisfy[T, P](errMessage, true, f), func() *Description {
+		return class(literal([]T{from})+" .. "+literal([]T{to}), Condition[T](f))
 	})
 }
 
@@ -23,8 +27,12 @@ func NotRange[T constraints.Ordered, P any](
 	errMessage string,
 	from, to T,
 ) Combinator[T, P, T] {
-	return Satisfy[T, P](errMessage, true, func(x T) bool {
+	f := func(x T) bool {
 		return x < from || x > to
+	}
+
+	return describe(Satisfy[T, P](errMessage, true, f), func() *Description {
+		return class("not "+literal([]T{from})+" .. "+literal([]T{to}), Condition[T](f))
 	})
 }
 
@@ -0,0 +1,207 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Chunk - result of parsing of chunk of input by ParseChunks.
+// Start and End are positions of the first item of chunk
+// and the position after the last one in the whole input.
+type Chunk[P any, S any] struct {
+	Start P
+	End   P
+	Value S
+	Err   Error[P]
+}
+
+type chunkBounds[P any] struct {
+	start  P
+	end    P
+	length int
+}
+
+// ParseChunks - split input to chunks by boundary combinator
+// and parse each chunk by c combinator concurrently in count of workers goroutines
+// (GOMAXPROCS if workers is not positive). Chunks are returned in input order,
+// positions of results and errors are positions in the whole input.
+// Every worker gets its own buffer made by buffer function, so buffer function
+// must make new buffer of the same input on each call,
+// and c combinator must be safe for concurrent use.
+// Combinator c must consume all items of chunk. Items consumed by boundary
+// don't belong to any chunk, empty chunks between adjacent boundaries
+// and after the last boundary are skipped, so blank lines and trailing newline
+// don't produce extra records.
+// Input is split sequentially before parsing, so boundary should be simple,
+// like Eq of newline.
+func ParseChunks[T any, P comparable, B any, S any](
+	buffer func() Buffer[T, P],
+	workers int,
+	boundary Combinator[T, P, B],
+	c Combinator[T, P, S],
+) []Chunk[P, S] {
+	chunks := splitChunks(buffer(), boundary)
+	return parseChunks(buffer, workers, chunks, c, false)
+}
+
+// ParseParallel - like ParseChunks, but returns values of chunks in input order
+// or error of the first failed chunk. Chunks after the failed one may be not parsed.
+func ParseParallel[T any, P comparable, B any, S any](
+	buffer func() Buffer[T, P],
+	workers int,
+	boundary Combinator[T, P, B],
+	c Combinator[T, P, S],
+) ([]S, Error[P]) {
+	chunks := splitChunks(buffer(), boundary)
+	parsed := parseChunks(buffer, workers, chunks, c, true)
+
+	result := make([]S, len(parsed))
+
+	for i, x := range parsed {
+		if x.Err != nil {
+			return nil, x.Err
+		}
+
+		result[i] = x.Value
+	}
+
+	return result, nil
+}
+
+// splitChunks - find bounds of not empty chunks, boundary is tried before each item,
+// boundaries which don't consume items are ignored.
+func splitChunks[T any, P comparable, B any](
+	buffer Buffer[T, P],
+	boundary Combinator[T, P, B],
+) []chunkBounds[P] {
+	chunks := make([]chunkBounds[P], 0)
+	current := chunkBounds[P]{start: buffer.Position()}
+
+	for !buffer.IsEOF() {
+		pos := buffer.Position()
+
+		if _, err := boundary(buffer); err == nil && buffer.Position() != pos {
+			if current.length > 0 {
+				current.end = pos
+				chunks = append(chunks, current)
+			}
+
+			current = chunkBounds[P]{start: buffer.Position()}
+
+			continue
+		}
+
+		if err := buffer.Seek(pos); err != nil {
+			break
+		}
+
+		if _, err := buffer.Read(true); err != nil {
+			break
+		}
+
+		current.length++
+	}
+
+	if current.length > 0 {
+		current.end = buffer.Position()
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+func parseChunks[T any, P comparable, S any](
+	buffer func() Buffer[T, P],
+	workers int,
+	chunks []chunkBounds[P],
+	c Combinator[T, P, S],
+	stopOnError bool,
+) []Chunk[P, S] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	workers = min(workers, len(chunks))
+
+	result := make([]Chunk[P, S], len(chunks))
+	jobs := make(chan int)
+
+	// failed - index of the first failed chunk,
+	// chunks after it are not parsed if stopOnError is true
+	var failed atomic.Int64
+	failed.Store(math.MaxInt64)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for range workers {
+		go func() {
+			defer wg.Done()
+
+			buf := buffer()
+
+			for i := range jobs {
+				if stopOnError && int64(i) > failed.Load() {
+					continue
+				}
+
+				result[i] = parseChunk(buf, chunks[i], c)
+
+				if result[i].Err != nil {
+					for {
+						current := failed.Load()
+						if current <= int64(i) || failed.CompareAndSwap(current, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	for i := range chunks {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return result
+}
+
+func parseChunk[T any, P comparable, S any](
+	buffer Buffer[T, P],
+	chunk chunkBounds[P],
+	c Combinator[T, P, S],
+) Chunk[P, S] {
+	result := Chunk[P, S]{Start: chunk.start, End: chunk.end}
+
+	if err := buffer.Seek(chunk.start); err != nil {
+		result.Err = NewParseError(chunk.start, err.Error())
+		return result
+	}
+
+	isolated := newIsolatedBuffer(buffer, chunk.length)
+
+	value, err := c(isolated)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if consumed := isolated.consumed(); consumed < chunk.length {
+		result.Err = NewParseError(
+			buffer.Position(),
+			fmt.Sprintf("expected %d items of chunk to be consumed, actual %d", chunk.length, consumed),
+		)
+
+		return result
+	}
+
+	result.Value = value
+
+	return result
+}
@@ -0,0 +1,375 @@
+package common
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// RuleProfile - statistics of named combinator collected by Profiler.
+type RuleProfile struct {
+	// Name - name of combinator.
+	Name string
+	// Calls - count of calls.
+	Calls int
+	// Successes - count of successful calls.
+	Successes int
+	// Failures - count of failed calls.
+	Failures int
+	// Total - duration of calls, including nested named combinators.
+	// Recursive calls are counted once.
+	Total time.Duration
+	// Self - duration of calls, excluding nested named combinators.
+	Self time.Duration
+	// Consumed - count of items consumed by successful calls.
+	Consumed int
+	// Backtracked - count of items consumed by failed calls before failure.
+	Backtracked int
+}
+
+// Profiler - sink of tracing events which collects statistics
+// of named combinators (see EnableTracing and Named).
+// Items are counted only for positions which are integers
+// or have Index method, like positions of strings and tokens.
+// Profiler doesn't support concurrent parsing.
+type Profiler struct {
+	mu      sync.Mutex
+	rules   map[string]*RuleProfile
+	samples map[string]*profileSample
+	stack   []profileFrame
+}
+
+type profileFrame struct {
+	name string
+	// children - duration of nested named combinators
+	children time.Duration
+	// recursive - true if combinator with the same name is on stack
+	recursive bool
+}
+
+type profileSample struct {
+	// stack - names of combinators from root to leaf
+	stack []string
+	calls int64
+	self  time.Duration
+}
+
+var _ PreviewSink = new(Profiler)
+
+// NewProfiler - make profiler.
+func NewProfiler() *Profiler {
+	p := new(Profiler)
+	p.Reset()
+	return p
+}
+
+// Reset - remove collected statistics.
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rules = make(map[string]*RuleProfile)
+	p.samples = make(map[string]*profileSample)
+	p.stack = nil
+}
+
+// NeedsPreview - false, because reading of preview affects measurements.
+func (p *Profiler) NeedsPreview() bool {
+	return false
+}
+
+// Emit - collect statistics from event.
+func (p *Profiler) Emit(event Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if event.Kind == EventEnter {
+		recursive := false
+		for _, frame := range p.stack {
+			if frame.name == event.Name {
+				recursive = true
+				break
+			}
+		}
+
+		p.stack = append(p.stack, profileFrame{name: event.Name, recursive: recursive})
+		return
+	}
+
+	if len(p.stack) == 0 {
+		return
+	}
+
+	frame := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+
+	if len(p.stack) > 0 {
+		p.stack[len(p.stack)-1].children += event.Duration
+	}
+
+	rule, exists := p.rules[event.Name]
+	if !exists {
+		rule = &RuleProfile{Name: event.Name}
+		p.rules[event.Name] = rule
+	}
+
+	self := event.Duration - frame.children
+	items, counted := itemsBetween(event.Start, event.End)
+
+	rule.Calls++
+	rule.Self += self
+
+	if !frame.recursive {
+		rule.Total += event.Duration
+	}
+
+	if event.Kind == EventExit {
+		rule.Successes++
+		if counted {
+			rule.Consumed += items
+		}
+	} else {
+		rule.Failures++
+		if counted {
+			rule.Backtracked += items
+		}
+	}
+
+	stack := make([]string, 0, len(p.stack)+1)
+	for _, x := range p.stack {
+		stack = append(stack, x.name)
+	}
+	stack = append(stack, event.Name)
+
+	key := strings.Join(stack, "\x00")
+
+	sample, exists := p.samples[key]
+	if !exists {
+		sample = &profileSample{stack: stack}
+		p.samples[key] = sample
+	}
+
+	sample.calls++
+	sample.self += self
+}
+
+func itemsBetween(start, end any) (int, bool) {
+	from, ok := positionIndex(start)
+	if !ok {
+		return 0, false
+	}
+
+	to, ok := positionIndex(end)
+	if !ok || to < from {
+		return 0, false
+	}
+
+	return to - from, true
+}
+
+func positionIndex(pos any) (int, bool) {
+	switch x := pos.(type) {
+	case int:
+		return x, true
+	case interface{ Index() int }:
+		return x.Index(), true
+	default:
+		return 0, false
+	}
+}
+
+// Profiles - statistics of named combinators sorted by cost:
+// self time, count of calls and name.
+func (p *Profiler) Profiles() []RuleProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]RuleProfile, 0, len(p.rules))
+	for _, rule := range p.rules {
+		result = append(result, *rule)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Self != result[j].Self {
+			return result[i].Self > result[j].Self
+		}
+
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// Report - write table of statistics sorted by cost to w.
+func (p *Profiler) Report(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "name\tcalls\tsuccesses\tfailures\ttotal\tself\tconsumed\tbacktracked")
+
+	for _, rule := range p.Profiles() {
+		fmt.Fprintf(
+			tw,
+			"%s\t%d\t%d\t%d\t%v\t%v\t%d\t%d\n",
+			rule.Name,
+			rule.Calls,
+			rule.Successes,
+			rule.Failures,
+			rule.Total,
+			rule.Self,
+			rule.Consumed,
+			rule.Backtracked,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// WritePprof - write gzipped profile in pprof format to w
+// with count of calls and self time for each stack of named combinators,
+// it can be viewed by "go tool pprof".
+func (p *Profiler) WritePprof(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	strs := []string{""}
+	index := map[string]int64{"": 0}
+	str := func(s string) int64 {
+		if i, exists := index[s]; exists {
+			return i
+		}
+
+		index[s] = int64(len(strs))
+		strs = append(strs, s)
+		return index[s]
+	}
+
+	profile := new(protoBuffer)
+
+	for _, x := range [][2]string{{"calls", "count"}, {"self", "nanoseconds"}} {
+		valueType := new(protoBuffer)
+		valueType.int(1, str(x[0]))
+		valueType.int(2, str(x[1]))
+		profile.message(1, valueType)
+	}
+
+	keys := make([]string, 0, len(p.samples))
+	for key := range p.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// location and function of each rule have the same id
+	ids := make(map[string]uint64)
+	names := make([]string, 0)
+
+	for _, key := range keys {
+		sample := p.samples[key]
+
+		locations := make([]uint64, 0, len(sample.stack))
+		for i := len(sample.stack) - 1; i >= 0; i-- {
+			name := sample.stack[i]
+
+			id, exists := ids[name]
+			if !exists {
+				names = append(names, name)
+				id = uint64(len(names))
+				ids[name] = id
+			}
+
+			locations = append(locations, id)
+		}
+
+		s := new(protoBuffer)
+		s.packed(1, locations)
+		s.packed(2, []uint64{uint64(sample.calls), uint64(sample.self.Nanoseconds())})
+		profile.message(2, s)
+	}
+
+	for i := range names {
+		line := new(protoBuffer)
+		line.int(1, int64(i+1))
+
+		location := new(protoBuffer)
+		location.int(1, int64(i+1))
+		location.message(4, line)
+		profile.message(4, location)
+	}
+
+	for i, name := range names {
+		function := new(protoBuffer)
+		function.int(1, int64(i+1))
+		function.int(2, str(name))
+		function.int(3, str(name))
+		profile.message(5, function)
+	}
+
+	periodType := new(protoBuffer)
+	periodType.int(1, str("calls"))
+	periodType.int(2, str("count"))
+
+	// string table is written after all strings are collected
+	profile.message(11, periodType)
+	profile.int(12, 1)
+
+	for _, s := range strs {
+		profile.bytes(6, []byte(s))
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(profile.data); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// protoBuffer - minimal encoder of protocol buffers messages.
+type protoBuffer struct {
+	data []byte
+}
+
+func (b *protoBuffer) varint(x uint64) {
+	for x >= 0x80 {
+		b.data = append(b.data, byte(x)|0x80)
+		x >>= 7
+	}
+
+	b.data = append(b.data, byte(x))
+}
+
+func (b *protoBuffer) int(field int, x int64) {
+	if x == 0 {
+		return
+	}
+
+	b.varint(uint64(field) << 3)
+	b.varint(uint64(x))
+}
+
+func (b *protoBuffer) bytes(field int, x []byte) {
+	b.varint(uint64(field)<<3 | 2)
+	b.varint(uint64(len(x)))
+	b.data = append(b.data, x...)
+}
+
+func (b *protoBuffer) message(field int, x *protoBuffer) {
+	b.bytes(field, x.data)
+}
+
+func (b *protoBuffer) packed(field int, xs []uint64) {
+	x := new(protoBuffer)
+	for _, v := range xs {
+		x.varint(v)
+	}
+
+	b.bytes(field, x.data)
+}
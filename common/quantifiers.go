@@ -5,21 +5,29 @@ import "fmt"
 // Optional - use c combinator to consume input data from buffer.
 // If it failed, than return def value.
 func Optional[T any, P any, S any](c Combinator[T, P, S], def S) Combinator[T, P, S] {
-	return func(buffer Buffer[T, P]) (S, Error[P]) {
+	point := newCoveragePoint("optional", "", func() []string {
+		return []string{"present", "absent"}
+	})
+
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
 		result, err := c(buffer)
 		if err != nil {
+			hitCoverage(point, 1)
 			return def, nil
 		}
 
+		hitCoverage(point, 0)
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindOptional, DescriptionOf(c))
+	})
 }
 
 // Many - accumulate data which returned by c consumer until it possible.
 // Stop on first error or end of buffer.
 // Returns an empty slice even if nothing could be parsed.
 func Many[T any, P any, S any](cap int, c Combinator[T, P, S]) Combinator[T, P, []S] {
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		result := make([]S, 0, cap)
 
 		for !buffer.IsEOF() {
@@ -32,7 +40,9 @@ func Many[T any, P any, S any](cap int, c Combinator[T, P, S]) Combinator[T, P,
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindMany, DescriptionOf(c))
+	})
 }
 
 // Some - accumulate data which returned by c consumer until it possible.
@@ -45,7 +55,7 @@ func Some[T any, P any, S any](
 ) Combinator[T, P, []S] {
 	parse := Many(cap, c)
 
-	return func(buffer Buffer[T, P]) ([]S, Error[P]) {
+	return describe(func(buffer Buffer[T, P]) ([]S, Error[P]) {
 		pos := buffer.Position()
 
 		// ignore err for coverage - many return at least empty slice
@@ -55,7 +65,83 @@ func Some[T any, P any, S any](
 		}
 
 		return result, nil
-	}
+	}, func() *Description {
+		return nested(KindSome, DescriptionOf(c))
+	})
+}
+
+// ManyFold - like Many, but combine data which returned by c combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value. Stop on first error or end of buffer.
+// Doesn't allocate memory for items, so it's useful to calculate sums or counts.
+// The same init value is used for each parsing, so it should not be mutated by f.
+func ManyFold[T any, P any, S any, A any](
+	init A,
+	c Combinator[T, P, S],
+	f func(A, S) A,
+) Combinator[T, P, A] {
+	return describe(func(buffer Buffer[T, P]) (A, Error[P]) {
+		result := init
+
+		for !buffer.IsEOF() {
+			x, err := c(buffer)
+			if err != nil {
+				break
+			}
+
+			result = f(result, x)
+		}
+
+		return result, nil
+	}, func() *Description {
+		return nested(KindMany, DescriptionOf(c))
+	})
+}
+
+// CountOnly - count data which returned by c consumer until it possible,
+// results are discarded. Stop on first error or end of buffer.
+// Returns zero even if nothing could be parsed.
+func CountOnly[T any, P any, S any](c Combinator[T, P, S]) Combinator[T, P, int] {
+	return ManyFold(0, c, func(n int, _ S) int { return n + 1 })
+}
+
+// SkipCount - skip exactly n occurrences of items parsed by skip combinator
+// before body combinator, results of skip are discarded without allocation
+// like in Count combinator. Returns error and seek to the start
+// if less than n occurrences could be parsed.
+// Skipped items are trivia of Lossless syntax tree.
+func SkipCount[T any, P any, S any, B any](
+	n int,
+	errMessage string,
+	skip Combinator[T, P, S],
+	body Combinator[T, P, B],
+) Combinator[T, P, B] {
+	var null B
+
+	return describe(func(buffer Buffer[T, P]) (B, Error[P]) {
+		start := buffer.Position()
+
+		for i := 0; i < n; i++ {
+			_, err := skipTrivia(buffer, skip)
+			if err != nil {
+				if seekErr := buffer.Seek(start); seekErr != nil {
+					prevErr := NewParseError(buffer.Position(), seekErr.Error(), err)
+					return null, NewParseError(start, errMessage, prevErr)
+				}
+
+				return null, NewParseError(start, errMessage, err)
+			}
+		}
+
+		return body(buffer)
+	}, func() *Description {
+		children := make([]*Description, 0, max(n, 0)+1)
+		for range n {
+			children = append(children, DescriptionOf(skip))
+		}
+
+		return nested(KindSequence, append(children, DescriptionOf(body))...)
+	})
 }
 
 // Count - try to read X item by c combinator.
@@ -99,7 +185,7 @@ func Quantifier[T any, P any, S any](
 
 	return func(buf Buffer[T, P]) ([]S, Error[P]) {
 		start := buf.Position()
-		result := make([]S, 0, to-from)
+		result := make([]S, 0, from)
 
 		for i := 0; i < to; i++ {
 			pos := buf.Position()
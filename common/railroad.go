@@ -0,0 +1,348 @@
+package common
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// railCharWidth - approximate width of character of monospace font
+	railCharWidth = 8
+	// railBoxHeight - height of boxes of terminals, classes and rules
+	railBoxHeight = 24
+	// railPadding - horizontal padding of text in box
+	railPadding = 8
+	// railGap - length of rail before and after box
+	railGap = 10
+	// railRadius - radius of turns of rails
+	railRadius = 10
+	// railSpace - vertical space between branches
+	railSpace = 8
+	// railMargin - margin of diagram
+	railMargin = 20
+	// railTitle - height of title of diagram
+	railTitle = 24
+)
+
+// railBox - element of railroad diagram, rail enters it on the left side
+// and leaves on the right side at the same height.
+type railBox struct {
+	width int
+	// up - height above rail
+	up int
+	// down - height below rail
+	down int
+	// draw - draw element with rail at (x, y)
+	draw func(b *strings.Builder, x, y int)
+}
+
+// WriteSVG - write railroad diagrams of rules of grammar to w in SVG format,
+// one diagram per rule (see Rules).
+func (d *Description) WriteSVG(w io.Writer) error {
+	rules := d.Rules()
+
+	boxes := make([]*railBox, len(rules))
+	width, height := 0, railMargin
+
+	for i, rule := range rules {
+		var body *Description
+		if len(rule.Children) > 0 {
+			body = rule.Children[0]
+		}
+
+		boxes[i] = railDiagram(body)
+		width = max(width, boxes[i].width, utf8.RuneCountInString(rule.Name)*railCharWidth)
+		height += railTitle + boxes[i].up + boxes[i].down + railMargin
+	}
+
+	width += 2 * railMargin
+
+	b := new(strings.Builder)
+
+	fmt.Fprintf(
+		b,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height,
+	)
+	b.WriteString(`<style>` +
+		`path, line { fill: none; stroke: #333; stroke-width: 2; } ` +
+		`rect { fill: #eef; stroke: #333; stroke-width: 2; } ` +
+		`rect.terminal { fill: #efe; } ` +
+		`rect.class { fill: #ffe; } ` +
+		`text { font-family: monospace; font-size: 13px; text-anchor: middle; dominant-baseline: central; } ` +
+		`text.title { font-size: 15px; font-weight: bold; text-anchor: start; }` +
+		`</style>` + "\n")
+
+	y := railMargin
+	for i, rule := range rules {
+		fmt.Fprintf(
+			b,
+			`<text class="title" x="%d" y="%d">%s</text>`+"\n",
+			railMargin, y+railTitle/2, html.EscapeString(rule.Name),
+		)
+
+		y += railTitle + boxes[i].up
+		boxes[i].draw(b, railMargin, y)
+		y += boxes[i].down + railMargin
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// railDiagram - body of rule between start and end marks.
+func railDiagram(d *Description) *railBox {
+	var body *railBox
+	if d == nil {
+		body = railEmpty()
+	} else {
+		body = railLayout(d)
+	}
+
+	mark := func(b *strings.Builder, x, y int) {
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", x, y-8, x, y+8)
+	}
+
+	return &railBox{
+		width: body.width + 2*railGap,
+		up:    max(body.up, 8),
+		down:  max(body.down, 8),
+		draw: func(b *strings.Builder, x, y int) {
+			mark(b, x, y)
+			railLine(b, x, y, x+railGap, y)
+			body.draw(b, x+railGap, y)
+			railLine(b, x+railGap+body.width, y, x+2*railGap+body.width, y)
+			mark(b, x+2*railGap+body.width, y)
+		},
+	}
+}
+
+func railLayout(d *Description) *railBox {
+	child := func(i int) *railBox {
+		if i < len(d.Children) {
+			return railLayout(d.Children[i])
+		}
+
+		return railText("unknown", "class")
+	}
+
+	switch d.Kind {
+	case KindTerminal:
+		return railText(d.Name, "terminal")
+	case KindClass:
+		return railText(d.Name, "class")
+	case KindRule, KindRef:
+		return railText(d.Name, "rule")
+	case KindEOF:
+		return railText("end of input", "class")
+	case KindLookAhead, KindNotFollowedBy:
+		return railText(predicate(d), "class")
+	case KindSequence:
+		items := make([]*railBox, len(d.Children))
+		for i, x := range d.Children {
+			items[i] = railLayout(x)
+		}
+
+		return railSequence(items)
+	case KindChoice:
+		items := make([]*railBox, len(d.Children))
+		for i, x := range d.Children {
+			items[i] = railLayout(x)
+		}
+
+		return railChoice(items)
+	case KindOptional:
+		return railChoice([]*railBox{child(0), railEmpty()})
+	case KindMany:
+		return railChoice([]*railBox{railLoop(child(0), railEmpty()), railEmpty()})
+	case KindSome:
+		return railLoop(child(0), railEmpty())
+	case KindSepBy:
+		return railChoice([]*railBox{railLoop(child(0), child(1)), railEmpty()})
+	case KindSepBy1:
+		return railLoop(child(0), child(1))
+	default:
+		return railText("unknown", "class")
+	}
+}
+
+func railLine(b *strings.Builder, x1, y1, x2, y2 int) {
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", x1, y1, x2, y2)
+}
+
+// railText - box with text, terminals are drawn with rounded corners.
+func railText(text, class string) *railBox {
+	inner := utf8.RuneCountInString(text)*railCharWidth + 2*railPadding
+	rounded := 0
+	if class == "terminal" {
+		rounded = railBoxHeight / 2
+	}
+
+	return &railBox{
+		width: inner + 2*railGap,
+		up:    railBoxHeight / 2,
+		down:  railBoxHeight / 2,
+		draw: func(b *strings.Builder, x, y int) {
+			railLine(b, x, y, x+railGap, y)
+			fmt.Fprintf(
+				b,
+				`<rect class="%s" x="%d" y="%d" width="%d" height="%d" rx="%d"/>`+"\n",
+				class, x+railGap, y-railBoxHeight/2, inner, railBoxHeight, rounded,
+			)
+			fmt.Fprintf(
+				b,
+				`<text x="%d" y="%d">%s</text>`+"\n",
+				x+railGap+inner/2, y, html.EscapeString(text),
+			)
+			railLine(b, x+railGap+inner, y, x+2*railGap+inner, y)
+		},
+	}
+}
+
+func railEmpty() *railBox {
+	return &railBox{
+		width: 2 * railGap,
+		draw: func(b *strings.Builder, x, y int) {
+			railLine(b, x, y, x+2*railGap, y)
+		},
+	}
+}
+
+func railSequence(items []*railBox) *railBox {
+	if len(items) == 0 {
+		return railEmpty()
+	}
+
+	box := new(railBox)
+	for _, item := range items {
+		box.width += item.width
+		box.up = max(box.up, item.up)
+		box.down = max(box.down, item.down)
+	}
+
+	box.draw = func(b *strings.Builder, x, y int) {
+		for _, item := range items {
+			item.draw(b, x, y)
+			x += item.width
+		}
+	}
+
+	return box
+}
+
+// railChoice - the first item on rail, other items below it.
+func railChoice(items []*railBox) *railBox {
+	if len(items) == 0 {
+		return railEmpty()
+	}
+
+	if len(items) == 1 {
+		return items[0]
+	}
+
+	inner := 0
+	for _, item := range items {
+		inner = max(inner, item.width)
+	}
+
+	box := &railBox{
+		width: inner + 4*railRadius,
+		up:    items[0].up,
+		down:  items[0].down,
+	}
+
+	// offsets - distance from rail to rail of each item
+	offsets := make([]int, len(items))
+	for i := 1; i < len(items); i++ {
+		offsets[i] = box.down + railSpace + max(items[i].up, railRadius)
+		box.down = offsets[i] + items[i].down
+	}
+
+	box.draw = func(b *strings.Builder, x, y int) {
+		left, right := x+2*railRadius, x+box.width-2*railRadius
+
+		railLine(b, x, y, left, y)
+		items[0].draw(b, left, y)
+		railLine(b, left+items[0].width, y, x+box.width, y)
+
+		for i := 1; i < len(items); i++ {
+			yi := y + offsets[i]
+
+			fmt.Fprintf(
+				b,
+				`<path d="M%d %d Q%d %d %d %d L%d %d Q%d %d %d %d"/>`+"\n",
+				x, y,
+				x+railRadius, y, x+railRadius, y+railRadius,
+				x+railRadius, yi-railRadius,
+				x+railRadius, yi, left, yi,
+			)
+
+			items[i].draw(b, left, yi)
+			railLine(b, left+items[i].width, yi, right, yi)
+
+			fmt.Fprintf(
+				b,
+				`<path d="M%d %d Q%d %d %d %d L%d %d Q%d %d %d %d"/>`+"\n",
+				right, yi,
+				right+railRadius, yi, right+railRadius, yi-railRadius,
+				right+railRadius, y+railRadius,
+				right+railRadius, y, x+box.width, y,
+			)
+		}
+	}
+
+	return box
+}
+
+// railLoop - item on rail and rail back to its beginning below it through separator.
+func railLoop(item, separator *railBox) *railBox {
+	inner := max(item.width, separator.width)
+	offset := item.down + railSpace + max(separator.up, railRadius)
+
+	box := &railBox{
+		width: inner + 4*railRadius,
+		up:    item.up,
+		down:  offset + separator.down,
+	}
+
+	box.draw = func(b *strings.Builder, x, y int) {
+		left, right := x+2*railRadius, x+box.width-2*railRadius
+		back := y + offset
+
+		railLine(b, x, y, left, y)
+		item.draw(b, left, y)
+		railLine(b, left+item.width, y, x+box.width, y)
+
+		fmt.Fprintf(
+			b,
+			`<path d="M%d %d Q%d %d %d %d L%d %d Q%d %d %d %d"/>`+"\n",
+			right, y,
+			right+railRadius, y, right+railRadius, y+railRadius,
+			right+railRadius, back-railRadius,
+			right+railRadius, back, right, back,
+		)
+
+		separator.draw(b, left, back)
+		railLine(b, left+separator.width, back, right, back)
+
+		fmt.Fprintf(
+			b,
+			`<path d="M%d %d Q%d %d %d %d L%d %d Q%d %d %d %d"/>`+"\n",
+			left, back,
+			x+railRadius, back, x+railRadius, back-railRadius,
+			x+railRadius, y+railRadius,
+			x+railRadius, y, left, y,
+		)
+	}
+
+	return box
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"io"
+	"regexp"
+)
+
+// regexReader - adapter to read items of buffer by regexp package.
+// Every item is reported as one byte wide, so offsets of
+// the match are indexes of read items.
+type regexReader[T any, P any] struct {
+	buffer    Buffer[T, P]
+	toRune    func(T) rune
+	items     []T
+	positions []P
+}
+
+func (r *regexReader[T, P]) ReadRune() (rune, int, error) {
+	x, err := r.buffer.Read(true)
+	if err != nil {
+		return 0, 0, io.EOF
+	}
+
+	r.items = append(r.items, x)
+	r.positions = append(r.positions, r.buffer.Position())
+
+	return r.toRune(x), 1, nil
+}
+
+// Regex - match regular expression re at the current position,
+// items of buffer are converted to runes by toRune function.
+// Returns the match and capture groups (nil for groups which did not participate
+// in the match), keep position after the match.
+// Matches which started after the current position are ignored,
+// so re should be anchored by \A to avoid scanning the rest of buffer.
+// If it falls, it returns buffer to the previous position.
+func Regex[T any, P any](
+	errMessage string,
+	re *regexp.Regexp,
+	toRune func(T) rune,
+) Combinator[T, P, [][]T] {
+	return func(buffer Buffer[T, P]) ([][]T, Error[P]) {
+		pos := buffer.Position()
+
+		reader := &regexReader[T, P]{
+			buffer:    buffer,
+			toRune:    toRune,
+			positions: []P{pos},
+		}
+
+		loc := re.FindReaderSubmatchIndex(reader)
+
+		if loc == nil || loc[0] != 0 {
+			if err := buffer.Seek(pos); err != nil {
+				return nil, NewParseError(pos, err.Error())
+			}
+
+			return nil, NewParseError(pos, errMessage)
+		}
+
+		if loc[1] < len(reader.items) {
+			if err := buffer.Seek(reader.positions[loc[1]]); err != nil {
+				return nil, NewParseError(pos, err.Error())
+			}
+		}
+
+		result := make([][]T, len(loc)/2)
+
+		for i := range result {
+			from, to := loc[i*2], loc[i*2+1]
+			if from >= 0 {
+				result[i] = reader.items[from:to:to]
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"iter"
+)
+
+// Releaser - buffer which can release items before current position,
+// like streaming buffers which keep only window of input in memory.
+// Buffer can't seek before position of the last release.
+type Releaser interface {
+	Release()
+}
+
+// ReadErrorer - buffer which reads input from external source
+// and keeps error of reading, end of buffer is reported after error.
+type ReadErrorer interface {
+	ReadErr() error
+}
+
+// ParseSeq - apply record combinator to buffer repeatedly until end of buffer,
+// yielding each record as soon as it's parsed. Iteration stops after the first error.
+// Items of parsed records are released if buffer implements Releaser,
+// so streaming input can be processed in constant memory.
+// Record combinator which doesn't consume items is an error, because it would loop forever.
+func ParseSeq[T any, P comparable, S any](
+	buffer Buffer[T, P],
+	record Combinator[T, P, S],
+) iter.Seq2[S, Error[P]] {
+	return ParseAll(buffer, Const[T, P](struct{}{}), record)
+}
+
+// ParseAll - like ParseSeq, but records are separated by data readed by sep combinator,
+// separator after the last record is optional, like in newline-delimited formats.
+func ParseAll[T any, P comparable, B any, S any](
+	buffer Buffer[T, P],
+	sep Combinator[T, P, B],
+	record Combinator[T, P, S],
+) iter.Seq2[S, Error[P]] {
+	return func(yield func(S, Error[P]) bool) {
+		var null S
+
+		for {
+			if buffer.IsEOF() {
+				if err := readError(buffer); err != nil {
+					yield(null, err)
+				}
+
+				return
+			}
+
+			pos := buffer.Position()
+
+			result, err := record(buffer)
+			if err == nil && buffer.Position() == pos {
+				err = NewParseError(pos, "record combinator doesn't consume input")
+			}
+
+			if err != nil {
+				if readErr := readError(buffer); readErr != nil {
+					err = readErr
+				}
+
+				yield(null, err)
+				return
+			}
+
+			if !buffer.IsEOF() {
+				if _, err := sep(buffer); err != nil {
+					if readErr := readError(buffer); readErr != nil {
+						err = readErr
+					}
+
+					if yield(result, nil) {
+						yield(null, err)
+					}
+
+					return
+				}
+			}
+
+			if releaser, ok := buffer.(Releaser); ok {
+				releaser.Release()
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readError - error of reading of buffer as ParseError, nil if there is no error.
+func readError[T any, P any](buffer Buffer[T, P]) Error[P] {
+	if x, ok := buffer.(ReadErrorer); ok {
+		if err := x.ReadErr(); err != nil {
+			return NewParseError(buffer.Position(), err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,487 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind - kind of tracing event.
+type EventKind int
+
+const (
+	// EventEnter - named combinator is called.
+	EventEnter EventKind = iota
+	// EventExit - named combinator parsed input successfully.
+	EventExit
+	// EventBacktrack - named combinator failed.
+	EventBacktrack
+)
+
+// String - return name of event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventEnter:
+		return "enter"
+	case EventExit:
+		return "exit"
+	case EventBacktrack:
+		return "backtrack"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// Event - tracing event emitted by Named combinator.
+type Event struct {
+	Kind EventKind
+	// Name - name of combinator.
+	Name string
+	// Depth - count of named combinators which are called before and not finished yet.
+	Depth int
+	// Start - position of buffer before parsing.
+	Start any
+	// End - position of buffer after parsing, nil for enter event.
+	End any
+	// Preview - preview of input at start position.
+	Preview string
+	// Duration - duration of parsing, zero for enter event.
+	Duration time.Duration
+	// Err - error of combinator for backtrack event.
+	Err error
+	// Result - result of combinator for exit event.
+	Result any
+}
+
+// Sink - receiver of tracing events.
+type Sink interface {
+	Emit(event Event)
+}
+
+// PreviewSink - sink which tells if it needs Preview of enter events,
+// input is not read for preview if NeedsPreview returns false.
+// Preview is read for sinks which don't implement this interface.
+type PreviewSink interface {
+	Sink
+	NeedsPreview() bool
+}
+
+// SinkFunc - function which implements Sink interface.
+type SinkFunc func(event Event)
+
+// Emit - call f with event.
+func (f SinkFunc) Emit(event Event) {
+	f(event)
+}
+
+// previewLength - count of items in preview of input.
+const previewLength = 16
+
+// Peeker - buffer which can return up to n next items without reading them,
+// so preview of input doesn't change state of buffer,
+// like examined items of incremental buffer.
+type Peeker[T any] interface {
+	Peek(n int) []T
+}
+
+// Wrapper - buffer which reads items of another buffer,
+// like buffers of Node and Isolate combinators.
+// Wrappers made by combinators must implement it to keep state of parsing,
+// like depth of tracing events and sink of Debug.
+type Wrapper[T any, P any] interface {
+	Unwrap() Buffer[T, P]
+}
+
+type tracer struct {
+	sink  Sink
+	names map[string]struct{}
+	// preview - read preview of input for enter events
+	preview bool
+
+	mu sync.Mutex
+	// depths - depth of each parse, keyed by underlying buffer of parse
+	depths map[any]int
+}
+
+var activeTracer atomic.Pointer[tracer]
+
+// tracedBuffer - buffer of one parse which emits events to own tracer
+// instead of tracer enabled by EnableTracing (see Debug).
+type tracedBuffer[T any, P any] struct {
+	buffer Buffer[T, P]
+	tracer *tracer
+}
+
+var _ Buffer[rune, int] = new(tracedBuffer[rune, int])
+
+// Read - read next item, if greedy buffer keep position after reading.
+func (b *tracedBuffer[T, P]) Read(greedy bool) (T, error) {
+	return b.buffer.Read(greedy)
+}
+
+// Seek - change buffer position.
+func (b *tracedBuffer[T, P]) Seek(position P) error {
+	return b.buffer.Seek(position)
+}
+
+// Position - return current buffer position
+func (b *tracedBuffer[T, P]) Position() P {
+	return b.buffer.Position()
+}
+
+// IsEOF - true if buffer ended.
+func (b *tracedBuffer[T, P]) IsEOF() bool {
+	return b.buffer.IsEOF()
+}
+
+// Peek - return up to n next items without reading them.
+func (b *tracedBuffer[T, P]) Peek(n int) []T {
+	return peek(b.buffer, n)
+}
+
+// Unwrap - return wrapped buffer.
+func (b *tracedBuffer[T, P]) Unwrap() Buffer[T, P] {
+	return b.buffer
+}
+
+func (b *tracedBuffer[T, P]) ownTracer() *tracer {
+	return b.tracer
+}
+
+// tracerOf - tracer of parse of buffer, own tracer of traced buffer
+// or tracer enabled by EnableTracing.
+func tracerOf[T any, P any](buffer Buffer[T, P]) *tracer {
+	for {
+		switch x := buffer.(type) {
+		case interface{ ownTracer() *tracer }:
+			return x.ownTracer()
+		case Wrapper[T, P]:
+			buffer = x.Unwrap()
+		default:
+			return activeTracer.Load()
+		}
+	}
+}
+
+// EnableTracing - emit events of Named combinators with names to sink,
+// events of all Named combinators are emitted if names are not passed.
+// Depth of events is counted for each parsed buffer separately,
+// so concurrent parsers can be traced by the same sink.
+func EnableTracing(sink Sink, names ...string) {
+	t := newTracer(sink)
+
+	if len(names) > 0 {
+		t.names = make(map[string]struct{}, len(names))
+		for _, name := range names {
+			t.names[name] = struct{}{}
+		}
+	}
+
+	activeTracer.Store(t)
+}
+
+func newTracer(sink Sink) *tracer {
+	t := &tracer{sink: sink, depths: make(map[any]int)}
+
+	t.preview = true
+	if x, ok := sink.(PreviewSink); ok {
+		t.preview = x.NeedsPreview()
+	}
+
+	return t
+}
+
+// DisableTracing - stop emitting of events enabled by EnableTracing.
+func DisableTracing() {
+	activeTracer.Store(nil)
+}
+
+func (t *tracer) enabled(name string) bool {
+	if t.names == nil {
+		return true
+	}
+
+	_, exists := t.names[name]
+	return exists
+}
+
+func (t *tracer) enter(parse any) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	depth := t.depths[parse]
+	t.depths[parse] = depth + 1
+
+	return depth
+}
+
+func (t *tracer) leave(parse any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if depth := t.depths[parse] - 1; depth > 0 {
+		t.depths[parse] = depth
+	} else {
+		delete(t.depths, parse)
+	}
+}
+
+// underlying - buffer of the whole parse, wrapped by buffers of nested combinators.
+func underlying[T any, P any](buffer Buffer[T, P]) Buffer[T, P] {
+	for {
+		w, ok := buffer.(Wrapper[T, P])
+		if !ok {
+			return buffer
+		}
+
+		buffer = w.Unwrap()
+	}
+}
+
+// Named - give a name to c combinator for tracing (see EnableTracing).
+// If tracing is disabled it just calls c combinator.
+// Successful calls are counted by coverage (see EnableCoverage).
+// Described as rule with name (see DescriptionOf).
+func Named[T any, P any, S any](name string, c Combinator[T, P, S]) Combinator[T, P, S] {
+	point := newCoveragePoint("named", name, func() []string {
+		return []string{"success"}
+	})
+
+	return describe(func(buffer Buffer[T, P]) (S, Error[P]) {
+		var (
+			result S
+			err    Error[P]
+		)
+
+		if t := tracerOf(buffer); t != nil && t.enabled(name) {
+			result, err = traced(t, name, c, buffer)
+		} else {
+			result, err = c(buffer)
+		}
+
+		if err == nil {
+			hitCoverage(point, 0)
+		}
+
+		return result, err
+	}, func() *Description {
+		return &Description{Kind: KindRule, Name: name, Children: []*Description{DescriptionOf(c)}}
+	})
+}
+
+func traced[T any, P any, S any](
+	t *tracer,
+	name string,
+	c Combinator[T, P, S],
+	buffer Buffer[T, P],
+) (S, Error[P]) {
+	start := buffer.Position()
+	parse := underlying(buffer)
+	depth := t.enter(parse)
+
+	event := Event{
+		Kind:  EventEnter,
+		Name:  name,
+		Depth: depth,
+		Start: start,
+	}
+
+	if t.preview {
+		event.Preview = preview(buffer)
+	}
+
+	t.sink.Emit(event)
+
+	now := time.Now()
+	result, err := c(buffer)
+	duration := time.Since(now)
+
+	t.leave(parse)
+
+	event = Event{
+		Kind:     EventExit,
+		Name:     name,
+		Depth:    depth,
+		Start:    start,
+		End:      buffer.Position(),
+		Duration: duration,
+	}
+
+	if err != nil {
+		event.Kind = EventBacktrack
+		event.Err = err
+	} else {
+		event.Result = result
+	}
+
+	t.sink.Emit(event)
+
+	return result, err
+}
+
+// preview - preview of input at current position of buffer.
+func preview[T any, P any](buffer Buffer[T, P]) string {
+	return formatPreview(peek(buffer, previewLength))
+}
+
+// peek - return up to n next items of buffer without changing its position.
+func peek[T any, P any](buffer Buffer[T, P], n int) []T {
+	if p, ok := buffer.(Peeker[T]); ok {
+		return p.Peek(n)
+	}
+
+	pos := buffer.Position()
+	items := make([]T, 0, n)
+
+	for len(items) < n {
+		x, err := buffer.Read(true)
+		if err != nil {
+			break
+		}
+
+		items = append(items, x)
+	}
+
+	if len(items) > 0 {
+		if err := buffer.Seek(pos); err != nil {
+			return nil
+		}
+	}
+
+	return items
+}
+
+func formatPreview[T any](items []T) string {
+	switch x := any(items).(type) {
+	case []rune:
+		return string(x)
+	case []byte:
+		return fmt.Sprintf("%x", x)
+	default:
+		return fmt.Sprintf("%v", items)
+	}
+}
+
+type textSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// TextSink - write events to w as indented tree:
+// "> name at position "preview"" for enter event,
+// "< name at position (duration)" for exit event and
+// "! name at position: error (duration)" for backtrack event.
+func TextSink(w io.Writer) Sink {
+	return &textSink{w: w}
+}
+
+// Emit - write event to writer.
+func (s *textSink) Emit(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	indent := strings.Repeat("  ", event.Depth)
+
+	switch event.Kind {
+	case EventEnter:
+		fmt.Fprintf(s.w, "%s> %s at %v %q\n", indent, event.Name, event.Start, event.Preview)
+	case EventExit:
+		fmt.Fprintf(s.w, "%s< %s at %v (%v)\n", indent, event.Name, event.End, event.Duration)
+	case EventBacktrack:
+		fmt.Fprintf(s.w, "%s! %s at %v: %v (%v)\n", indent, event.Name, event.Start, event.Err, event.Duration)
+	}
+}
+
+type jsonSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+type jsonEvent struct {
+	Event    string `json:"event"`
+	Name     string `json:"name"`
+	Depth    int    `json:"depth"`
+	Start    string `json:"start"`
+	End      string `json:"end,omitempty"`
+	Preview  string `json:"preview,omitempty"`
+	Duration int64  `json:"duration_ns,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// JSONSink - write events to w as JSON lines,
+// positions are formatted as strings.
+func JSONSink(w io.Writer) Sink {
+	return &jsonSink{encoder: json.NewEncoder(w)}
+}
+
+// Emit - write event to writer.
+func (s *jsonSink) Emit(event Event) {
+	x := jsonEvent{
+		Event:    event.Kind.String(),
+		Name:     event.Name,
+		Depth:    event.Depth,
+		Start:    fmt.Sprint(event.Start),
+		Preview:  event.Preview,
+		Duration: event.Duration.Nanoseconds(),
+	}
+
+	if event.End != nil {
+		x.End = fmt.Sprint(event.End)
+	}
+
+	if event.Err != nil {
+		x.Error = event.Err.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.encoder.Encode(x)
+}
+
+type slogSink struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// SlogSink - write events to logger with level.
+func SlogSink(logger *slog.Logger, level slog.Level) Sink {
+	return &slogSink{logger: logger, level: level}
+}
+
+// Emit - write event to logger.
+func (s *slogSink) Emit(event Event) {
+	ctx := context.Background()
+
+	if !s.logger.Enabled(ctx, s.level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("name", event.Name),
+		slog.Int("depth", event.Depth),
+		slog.Any("start", event.Start),
+	}
+
+	if event.Kind == EventEnter {
+		attrs = append(attrs, slog.String("preview", event.Preview))
+	} else {
+		attrs = append(
+			attrs,
+			slog.Any("end", event.End),
+			slog.Duration("duration", event.Duration),
+		)
+	}
+
+	if event.Err != nil {
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+
+	s.logger.LogAttrs(ctx, s.level, "parsec "+event.Kind.String(), attrs...)
+}
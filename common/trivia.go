@@ -0,0 +1,218 @@
+package common
+
+// span - range of items [from, to) read by buffer.
+type span struct {
+	from int
+	to   int
+}
+
+// triviaBuffer - buffer which tracks items skipped as trivia
+// by Skip, SkipAfter, SkipMany and Padded combinators.
+type triviaBuffer interface {
+	consumed() int
+	markTrivia(from, to int)
+}
+
+// triviaOf - buffer which tracks trivia, buffer itself or buffer wrapped by it.
+func triviaOf[T any, P any](buffer Buffer[T, P]) (triviaBuffer, bool) {
+	for {
+		if b, ok := buffer.(triviaBuffer); ok {
+			return b, true
+		}
+
+		w, ok := buffer.(Wrapper[T, P])
+		if !ok {
+			return nil, false
+		}
+
+		buffer = w.Unwrap()
+	}
+}
+
+// skipTrivia - run skip combinator and mark consumed items as trivia.
+func skipTrivia[T any, P any, S any](
+	buffer Buffer[T, P],
+	skip Combinator[T, P, S],
+) (S, Error[P]) {
+	b, ok := triviaOf(buffer)
+	if !ok {
+		return skip(buffer)
+	}
+
+	from := b.consumed()
+
+	result, err := skip(buffer)
+	if err != nil {
+		return result, err
+	}
+
+	b.markTrivia(from, b.consumed())
+
+	return result, nil
+}
+
+// Lossless - like Node, but produce lossless syntax tree:
+// items skipped by Skip, SkipAfter, SkipMany and Padded combinators
+// inside c are attached as trivia to the nearest token node.
+//
+// Token nodes are nodes without children, items of internal nodes
+// not covered by children become anonymous token nodes with empty name.
+// Trivia before a token is attached to it as Leading,
+// trivia after the last token is attached to it as Trailing.
+// Items and positions of token nodes exclude trivia,
+// items of internal nodes include it.
+//
+// Source of the tree is equal to the matched input,
+// so edited tree can be printed back without losing of whitespaces or comments.
+func Lossless[T any, P comparable, S any](
+	name string,
+	c Combinator[T, P, S],
+) Combinator[T, P, *SyntaxNode[T, P]] {
+	return func(buffer Buffer[T, P]) (*SyntaxNode[T, P], Error[P]) {
+		if parent, ok := treeOf(buffer); ok && parent.lossless {
+			node, _, err := parseNode(name, c, buffer, true)
+			return node, err
+		}
+
+		node, tree, err := parseNode(name, c, buffer, true)
+		if err != nil {
+			return nil, err
+		}
+
+		a := &triviaAttacher[T, P]{
+			items:     tree.items,
+			positions: tree.positions,
+			trivia:    make([]bool, len(tree.items)),
+		}
+
+		for _, x := range tree.trivia {
+			for i := x.from; i < x.to; i++ {
+				a.trivia[i] = true
+			}
+		}
+
+		a.node(node, 0)
+
+		if a.last != nil {
+			a.last.Trailing = a.pending
+		} else {
+			node.Leading = a.pending
+		}
+
+		return node, nil
+	}
+}
+
+type triviaAttacher[T any, P any] struct {
+	items     []T
+	positions []P
+	// trivia[i] - true if i-th item is trivia
+	trivia []bool
+	// pending - trivia which is not attached yet
+	pending []T
+	// last - last token
+	last *SyntaxNode[T, P]
+}
+
+func (a *triviaAttacher[T, P]) node(n *SyntaxNode[T, P], from int) {
+	to := from + n.size
+
+	if len(n.Children) == 0 {
+		a.leaf(n, from, to)
+		return
+	}
+
+	children := make([]*SyntaxNode[T, P], 0, len(n.Children))
+	pos := from
+
+	for _, child := range n.Children {
+		start := from + child.offset
+		children = a.gap(children, pos, start)
+
+		a.node(child, start)
+		children = append(children, child)
+
+		pos = start + child.size
+	}
+
+	n.Children = a.gap(children, pos, to)
+}
+
+func (a *triviaAttacher[T, P]) leaf(n *SyntaxNode[T, P], from, to int) {
+	runs := a.runs(from, to)
+
+	switch len(runs) {
+	case 0:
+		a.pending = append(a.pending, a.items[from:to]...)
+		n.Items = a.items[from:from:from]
+	case 1:
+		x := runs[0]
+		a.pending = append(a.pending, a.items[from:x.from]...)
+
+		n.Items = a.items[x.from:x.to:x.to]
+		n.Start = a.positions[x.from]
+		n.End = a.positions[x.to]
+		a.token(n)
+
+		a.pending = append(a.pending, a.items[x.to:to]...)
+	default:
+		n.Children = a.gap(nil, from, to)
+	}
+}
+
+// gap - attach trivia of items which are not covered by child nodes
+// and append other items to children as anonymous tokens.
+func (a *triviaAttacher[T, P]) gap(
+	children []*SyntaxNode[T, P],
+	from, to int,
+) []*SyntaxNode[T, P] {
+	pos := from
+
+	for _, x := range a.runs(from, to) {
+		a.pending = append(a.pending, a.items[pos:x.from]...)
+
+		node := &SyntaxNode[T, P]{
+			Start: a.positions[x.from],
+			End:   a.positions[x.to],
+			Items: a.items[x.from:x.to:x.to],
+			size:  x.to - x.from,
+		}
+
+		a.token(node)
+		children = append(children, node)
+
+		pos = x.to
+	}
+
+	a.pending = append(a.pending, a.items[pos:to]...)
+
+	return children
+}
+
+// runs - ranges of items which are not trivia.
+func (a *triviaAttacher[T, P]) runs(from, to int) []span {
+	result := make([]span, 0, 1)
+
+	for i := from; i < to; {
+		if a.trivia[i] {
+			i++
+			continue
+		}
+
+		j := i
+		for j < to && !a.trivia[j] {
+			j++
+		}
+
+		result = append(result, span{from: i, to: j})
+		i = j
+	}
+
+	return result
+}
+
+func (a *triviaAttacher[T, P]) token(n *SyntaxNode[T, P]) {
+	n.Leading = a.pending
+	a.pending = nil
+	a.last = n
+}
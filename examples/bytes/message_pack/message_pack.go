@@ -30,45 +30,45 @@ func MessagePack() common.Combinator[byte, int, Type] {
 		0xc6: binaryParser[uint32](4),
 
 		// ext
-		0xc7: extParser("ext8", bytes.ReadAs[int8](1, "ext8", binary.BigEndian)),
-		0xc8: extParser("ext16", bytes.ReadAs[int16](2, "ext16", binary.BigEndian)),
+		0xc7: extParser("ext8", bytes.Int8("ext8")),
+		0xc8: extParser("ext16", bytes.Int16BE("ext16")),
 		0xc9: extParser("ext32", bytes.ReadAs[int32](3, "ext32", binary.BigEndian)),
 
 		// float
 		0xca: bytes.Cast(
-			bytes.ReadAs[Float32](4, "float32", binary.BigEndian),
-			func(f Float32) (Type, error) {
-				return f, nil
+			bytes.Float32BE("float32"),
+			func(f float32) (Type, error) {
+				return Float32(f), nil
 			},
 		),
 		0xcb: bytes.Cast(
-			bytes.ReadAs[Float64](8, "float64", binary.BigEndian),
-			func(f Float64) (Type, error) {
-				return f, nil
+			bytes.Float64BE("float64"),
+			func(f float64) (Type, error) {
+				return Float64(f), nil
 			},
 		),
 
 		// uint
 		0xcc: bytes.Cast(
-			bytes.ReadAs[uint8](1, "uint8", binary.BigEndian),
+			bytes.Uint8("uint8"),
 			func(f uint8) (Type, error) {
 				return Unsigned8(f), nil
 			},
 		),
 		0xcd: bytes.Cast(
-			bytes.ReadAs[uint16](2, "uint16", binary.BigEndian),
+			bytes.Uint16BE("uint16"),
 			func(f uint16) (Type, error) {
 				return Unsigned16(f), nil
 			},
 		),
 		0xce: bytes.Cast(
-			bytes.ReadAs[uint32](4, "uint32", binary.BigEndian),
+			bytes.Uint32BE("uint32"),
 			func(f uint32) (Type, error) {
 				return Unsigned32(f), nil
 			},
 		),
 		0xcf: bytes.Cast(
-			bytes.ReadAs[uint64](8, "uint64", binary.BigEndian),
+			bytes.Uint64BE("uint64"),
 			func(f uint64) (Type, error) {
 				return Unsigned64(f), nil
 			},
@@ -76,25 +76,25 @@ func MessagePack() common.Combinator[byte, int, Type] {
 
 		// int
 		0xd0: bytes.Cast(
-			bytes.ReadAs[int8](1, "int8", binary.BigEndian),
+			bytes.Int8("int8"),
 			func(f int8) (Type, error) {
 				return Signed8(f), nil
 			},
 		),
 		0xd1: bytes.Cast(
-			bytes.ReadAs[int16](2, "int16", binary.BigEndian),
+			bytes.Int16BE("int16"),
 			func(f int16) (Type, error) {
 				return Signed16(f), nil
 			},
 		),
 		0xd2: bytes.Cast(
-			bytes.ReadAs[int32](4, "int32", binary.BigEndian),
+			bytes.Int32BE("int32"),
 			func(f int32) (Type, error) {
 				return Signed32(f), nil
 			},
 		),
 		0xd3: bytes.Cast(
-			bytes.ReadAs[int64](8, "int64", binary.BigEndian),
+			bytes.Int64BE("int64"),
 			func(f int64) (Type, error) {
 				return Signed64(f), nil
 			},
@@ -108,9 +108,9 @@ func MessagePack() common.Combinator[byte, int, Type] {
 		0xd8: extParser("fixext(16)", bytes.Const[uint8](16)),
 
 		// strings
-		0xd9: stringParser(bytes.ReadAs[uint8](1, "string", binary.BigEndian)),
-		0xda: stringParser(bytes.ReadAs[uint16](2, "string", binary.BigEndian)),
-		0xdb: stringParser(bytes.ReadAs[uint32](4, "string", binary.BigEndian)),
+		0xd9: stringParser(bytes.Uint8("string")),
+		0xda: stringParser(bytes.Uint16BE("string")),
+		0xdb: stringParser(bytes.Uint32BE("string")),
 	}
 
 	// positive fixint
@@ -149,13 +149,13 @@ func MessagePack() common.Combinator[byte, int, Type] {
 
 	// array 16
 	cases[0xdc] = arrayParser(
-		bytes.ReadAs[uint16](2, "", binary.BigEndian),
+		bytes.Uint16BE(""),
 		valuesParser,
 	)
 
 	// array 32
 	cases[0xdd] = arrayParser(
-		bytes.ReadAs[uint32](4, "expected array32", binary.BigEndian),
+		bytes.Uint32BE("expected array32"),
 		valuesParser,
 	)
 
@@ -169,13 +169,13 @@ func MessagePack() common.Combinator[byte, int, Type] {
 
 	// map 16 parser
 	cases[0xde] = mapParser(
-		bytes.ReadAs[uint16](2, "expected map16", binary.BigEndian),
+		bytes.Uint16BE("expected map16"),
 		valuesParser,
 	)
 
 	// map 32 parser
 	cases[0xdf] = mapParser(
-		bytes.ReadAs[uint32](4, "expected map32", binary.BigEndian),
+		bytes.Uint32BE("expected map32"),
 		valuesParser,
 	)
 
@@ -303,7 +303,7 @@ func extParser[T bytes.Number](
 	errMessage string,
 	parseSize common.Combinator[byte, int, T],
 ) common.Combinator[byte, int, Type] {
-	nameParser := bytes.ReadAs[int8](1, "name of ext type", binary.BigEndian)
+	nameParser := bytes.Int8("name of ext type")
 
 	return func(buffer common.Buffer[byte, int]) (Type, common.Error[int]) {
 		pos := buffer.Position()
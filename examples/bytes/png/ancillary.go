@@ -1,7 +1,6 @@
 package png
 
 import (
-	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -53,12 +52,6 @@ func AncillaryChunk(
 		bytes.Any(),
 	)
 
-	parseCRC := bytes.ReadAs[uint32](
-		4,
-		"expected 4 big endian bytes of CRC",
-		binary.BigEndian,
-	)
-
 	return func(buffer common.Buffer[byte, int]) (*Ancillary, common.Error[int]) {
 		var data []byte
 		var err common.Error[int]
@@ -70,16 +63,10 @@ func AncillaryChunk(
 			}
 		}
 
-		crc, err := parseCRC(buffer)
-		if err != nil {
-			return nil, err
-		}
-
 		return &Ancillary{
 			length:    size,
 			chunkType: chunkType,
 			data:      data,
-			crc:       crc,
 		}, nil
 	}
 }
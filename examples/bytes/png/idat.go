@@ -1,7 +1,6 @@
 package png
 
 import (
-	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -48,8 +47,6 @@ func IDATChunk(size uint32) common.Combinator[byte, int, *IDAT] {
 		bytes.Any(),
 	)
 
-	parseCRC := bytes.ReadAs[uint32](4, "expected four bytes of CRC", binary.BigEndian)
-
 	return func(buffer common.Buffer[byte, int]) (*IDAT, common.Error[int]) {
 		var data []byte
 		var err common.Error[int]
@@ -61,15 +58,9 @@ func IDATChunk(size uint32) common.Combinator[byte, int, *IDAT] {
 			}
 		}
 
-		crc, err := parseCRC(buffer)
-		if err != nil {
-			return nil, err
-		}
-
 		return &IDAT{
 			length: size,
 			data:   data,
-			crc:    crc,
 		}, nil
 	}
 }
@@ -1,11 +1,9 @@
 package png
 
 import (
-	"encoding/binary"
 	"fmt"
 	"strings"
 
-	. "github.com/okneniz/parsec/bytes"
 	"github.com/okneniz/parsec/common"
 )
 
@@ -48,14 +46,8 @@ func IENDChunk(size uint32) common.Combinator[byte, int, *IEND] {
 			)
 		}
 
-		crc, err := ReadAs[uint32](4, "4 bytes of CRC", binary.BigEndian)(buffer)
-		if err != nil {
-			return nil, err
-		}
-
 		return &IEND{
 			length: size,
-			crc:    crc,
 		}, nil
 	}
 }
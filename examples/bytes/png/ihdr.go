@@ -1,7 +1,6 @@
 package png
 
 import (
-	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -52,25 +51,32 @@ func (c *IHDR) String() string {
 	return b.String()
 }
 
+// ihdrSize - size of data of IHDR chunk.
+const ihdrSize = 13
+
 func IHDRChunk(size uint32) common.Combinator[byte, int, *IHDR] {
 	parseData := bytes.Count[byte](
-		int(size),
-		fmt.Sprintf("expected %d bytes of IHDR data", size),
+		ihdrSize,
+		fmt.Sprintf("expected %d bytes of IHDR data", ihdrSize),
 		bytes.Any(),
 	)
 
-	parseWidth := bytes.ReadAs[uint32](4, "expected 4 bytes of IHDR width", binary.BigEndian)
-	parseHeight := bytes.ReadAs[uint32](4, "expected 4 bytes of IHDR height", binary.BigEndian)
-	parseBitDepth := bytes.Satisfy("expected 1 byte of IHDR bit depth", true, common.Anything[byte])
-	parseColorType := bytes.Satisfy("expected 1 byte of color type", true, common.Anything[byte])
-	parseCompressionMethod := bytes.Satisfy("expected 1 byte of compression method", true, common.Anything[byte])
-	parseFilterMethod := bytes.Satisfy("expected 1 byte of filter method", true, common.Anything[byte])
-	parseInterfaceMethod := bytes.Satisfy("expected 1 byte of interface method", true, common.Anything[byte])
-	parseCRC := bytes.ReadAs[uint32](4, "expecte 4 bytes of CRC", binary.BigEndian)
+	parseFields := bytes.Isolate(
+		fmt.Sprintf("expected %d bytes of IHDR fields", ihdrSize),
+		ihdrSize,
+		bytes.ReadStruct[IHDR](),
+	)
 
 	return func(buffer common.Buffer[byte, int]) (*IHDR, common.Error[int]) {
 		pos := buffer.Position()
 
+		if size != ihdrSize {
+			return nil, common.NewParseError(
+				pos,
+				fmt.Sprintf("expected %d bytes of IHDR data, actual %d", ihdrSize, size),
+			)
+		}
+
 		data, err := parseData(buffer)
 		if err != nil {
 			return nil, err
@@ -80,59 +86,14 @@ func IHDRChunk(size uint32) common.Combinator[byte, int, *IHDR] {
 			return nil, common.NewParseError(buffer.Position(), seekErr.Error())
 		}
 
-		width, err := parseWidth(buffer)
+		result, err := parseFields(buffer)
 		if err != nil {
 			return nil, err
 		}
 
-		height, err := parseHeight(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		bitDepth, err := parseBitDepth(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		colorType, err := parseColorType(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		compressionMethod, err := parseCompressionMethod(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		filterMethod, err := parseFilterMethod(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		interfaceMethod, err := parseInterfaceMethod(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		crc, err := parseCRC(buffer)
-		if err != nil {
-			return nil, err
-		}
-
-		result := &IHDR{
-			length:            uint32(size),
-			data:              data,
-			crc:               crc,
-			Width:             width,
-			Height:            height,
-			BitDepth:          bitDepth,
-			ColorType:         colorType,
-			CompressionMethod: compressionMethod,
-			FilterMethod:      filterMethod,
-			InterfaceMethod:   interfaceMethod,
-		}
+		result.length = size
+		result.data = data
 
-		return result, nil
+		return &result, nil
 	}
 }
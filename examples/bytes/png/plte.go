@@ -85,12 +85,6 @@ func PLTEChunk(size uint32) common.Combinator[byte, int, *PLTE] {
 		bytes.Any(),
 	)
 
-	parseCRC := bytes.ReadAs[uint32](
-		4,
-		fmt.Sprintf("expected 4 bytes of CRC"),
-		binary.BigEndian,
-	)
-
 	return func(buffer common.Buffer[byte, int]) (*PLTE, common.Error[int]) {
 		pos := buffer.Position()
 
@@ -139,15 +133,9 @@ func PLTEChunk(size uint32) common.Combinator[byte, int, *PLTE] {
 			return nil, common.NewParseError(buffer.Position(), seekErr.Error())
 		}
 
-		crc, err := parseCRC(buffer)
-		if err != nil {
-			return nil, err
-		}
-
 		return &PLTE{
 			length:  size,
 			data:    data,
-			crc:     crc,
 			Entries: entries,
 		}, nil
 	}
@@ -2,6 +2,7 @@ package png
 
 import (
 	"encoding/binary"
+	"hash/crc32"
 
 	"github.com/okneniz/parsec/bytes"
 	"github.com/okneniz/parsec/common"
@@ -15,11 +16,7 @@ func PNG() common.Combinator[byte, int, *File] {
 		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
 	)
 
-	parseChunks := bytes.Some(
-		1,
-		"expected PNG chunks",
-		bytes.Try(parseChunk()),
-	)
+	parse := parseChunk()
 
 	return func(buffer common.Buffer[byte, int]) (*File, common.Error[int]) {
 		_, err := parseHeader(buffer)
@@ -27,9 +24,21 @@ func PNG() common.Combinator[byte, int, *File] {
 			return nil, err
 		}
 
-		chunks, err := parseChunks(buffer)
-		if err != nil {
-			return nil, err
+		if buffer.IsEOF() {
+			return nil, common.NewParseError(buffer.Position(), "expected PNG chunks")
+		}
+
+		// chunks are read until the end of file,
+		// so errors of chunks (like invalid CRC) are not hidden
+		chunks := make([]Chunk, 0, 1)
+
+		for !buffer.IsEOF() {
+			chunk, err := parse(buffer)
+			if err != nil {
+				return nil, err
+			}
+
+			chunks = append(chunks, chunk)
 		}
 
 		return &File{chunks}, nil
@@ -43,18 +52,39 @@ func parseChunk() common.Combinator[byte, int, Chunk] {
 		binary.BigEndian,
 	)
 
-	typeOfChunk := bytes.Count(
+	parseCRC := bytes.ReadAs[uint32](
 		4,
-		"expecte 4 bytes of chunk type",
-		bytes.Any(),
+		"expected 4 big endian bytes of CRC",
+		binary.BigEndian,
 	)
 
+	crc := bytes.CRC32(crc32.IEEETable)
+
 	return func(buffer common.Buffer[byte, int]) (Chunk, common.Error[int]) {
 		length, err := lenghtOfChunk(buffer)
 		if err != nil {
 			return nil, err
 		}
 
+		// CRC is calculated on the chunk type and chunk data fields
+		return bytes.Checksum(
+			"invalid CRC of chunk",
+			crc,
+			parseChunkBody(length),
+			parseCRC,
+			withCRC,
+		)(buffer)
+	}
+}
+
+func parseChunkBody(length uint32) common.Combinator[byte, int, Chunk] {
+	typeOfChunk := bytes.Count(
+		4,
+		"expecte 4 bytes of chunk type",
+		bytes.Any(),
+	)
+
+	return func(buffer common.Buffer[byte, int]) (Chunk, common.Error[int]) {
 		chunkType, err := typeOfChunk(buffer)
 		if err != nil {
 			return nil, err
@@ -76,3 +106,20 @@ func parseChunk() common.Combinator[byte, int, Chunk] {
 		}
 	}
 }
+
+func withCRC(chunk Chunk, crc uint32) Chunk {
+	switch c := chunk.(type) {
+	case *IHDR:
+		c.crc = crc
+	case *PLTE:
+		c.crc = crc
+	case *IDAT:
+		c.crc = crc
+	case *IEND:
+		c.crc = crc
+	case *Ancillary:
+		c.crc = crc
+	}
+
+	return chunk
+}
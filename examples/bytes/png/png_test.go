@@ -1,16 +1,53 @@
 package png
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/okneniz/parsec/bytes"
+	"github.com/okneniz/parsec/common"
 )
 
 func TestPNG(t *testing.T) {
+	t.Parallel()
+
 	comb := PNG()
 	result, err := bytes.ParseFile("nibbler.png", comb)
 	assert.NoError(t, err)
 	t.Log("\n", result)
 }
+
+func TestPNG_InvalidCRC(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("nibbler.png")
+	assert.NoError(t, err)
+
+	// corrupt the width of image in IHDR chunk
+	data[16] ^= 0xff
+
+	_, err = bytes.Parse(data, PNG())
+	// CRC of IHDR chunk follows 8 bytes of header, 4 bytes of length,
+	// 4 bytes of type and 13 bytes of data
+	assert.EqualError(t, err, "Parse error at 29: invalid CRC of chunk: expected 0xcdb46899, actual 0xf05ae43f")
+
+	var parseErr common.Error[int]
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 29, parseErr.Position())
+	}
+}
+
+func TestPNG_InvalidIHDRLength(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("nibbler.png")
+	assert.NoError(t, err)
+
+	// the last byte of big endian length of IHDR chunk
+	data[11] = 14
+
+	_, err = bytes.Parse(data, PNG())
+	assert.EqualError(t, err, "Parse error at 16: expected 13 bytes of IHDR data, actual 14")
+}
@@ -0,0 +1,393 @@
+// Code generated by parsecgen from calc.peg. DO NOT EDIT.
+
+package calc
+
+import (
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+)
+
+// Expr - result of rule:
+//
+//	Expr <- Spacing Sum !.
+type Expr struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Spacing *Spacing
+	Sum     *Sum
+}
+
+// ParseExpr - parse rule Expr.
+func ParseExpr(buffer common.Buffer[rune, strings.Position]) (*Expr, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcExprRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Expr{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Spacing:
+			text = append(text, x.Text...)
+			result.Spacing = x
+		case *Sum:
+			text = append(text, x.Text...)
+			result.Sum = x
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcExprRule common.Combinator[rune, strings.Position, []any]
+
+// Sum - result of rule:
+//
+//	Sum <- Product (AddOp Product)*
+type Sum struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Product []*Product
+	AddOp   []*AddOp
+}
+
+// ParseSum - parse rule Sum.
+func ParseSum(buffer common.Buffer[rune, strings.Position]) (*Sum, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcSumRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Sum{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Product:
+			text = append(text, x.Text...)
+			result.Product = append(result.Product, x)
+		case *AddOp:
+			text = append(text, x.Text...)
+			result.AddOp = append(result.AddOp, x)
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcSumRule common.Combinator[rune, strings.Position, []any]
+
+// Product - result of rule:
+//
+//	Product <- Value (MulOp Value)*
+type Product struct {
+	Text  string
+	Start strings.Position
+	End   strings.Position
+	Value []*Value
+	MulOp []*MulOp
+}
+
+// ParseProduct - parse rule Product.
+func ParseProduct(buffer common.Buffer[rune, strings.Position]) (*Product, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcProductRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Product{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Value:
+			text = append(text, x.Text...)
+			result.Value = append(result.Value, x)
+		case *MulOp:
+			text = append(text, x.Text...)
+			result.MulOp = append(result.MulOp, x)
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcProductRule common.Combinator[rune, strings.Position, []any]
+
+// Value - result of rule:
+//
+//	Value <- Number / "(" Spacing Sum ")" Spacing
+type Value struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Number  *Number
+	Spacing []*Spacing
+	Sum     *Sum
+}
+
+// ParseValue - parse rule Value.
+func ParseValue(buffer common.Buffer[rune, strings.Position]) (*Value, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcValueRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Value{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Number:
+			text = append(text, x.Text...)
+			result.Number = x
+		case *Spacing:
+			text = append(text, x.Text...)
+			result.Spacing = append(result.Spacing, x)
+		case *Sum:
+			text = append(text, x.Text...)
+			result.Sum = x
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcValueRule common.Combinator[rune, strings.Position, []any]
+
+// Number - result of rule:
+//
+//	Number <- [0-9]+ Spacing
+type Number struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Spacing *Spacing
+}
+
+// ParseNumber - parse rule Number.
+func ParseNumber(buffer common.Buffer[rune, strings.Position]) (*Number, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcNumberRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Number{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Spacing:
+			text = append(text, x.Text...)
+			result.Spacing = x
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcNumberRule common.Combinator[rune, strings.Position, []any]
+
+// AddOp - result of rule:
+//
+//	AddOp <- [+\-] Spacing
+type AddOp struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Spacing *Spacing
+}
+
+// ParseAddOp - parse rule AddOp.
+func ParseAddOp(buffer common.Buffer[rune, strings.Position]) (*AddOp, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcAddOpRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AddOp{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Spacing:
+			text = append(text, x.Text...)
+			result.Spacing = x
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcAddOpRule common.Combinator[rune, strings.Position, []any]
+
+// MulOp - result of rule:
+//
+//	MulOp <- [*/] Spacing
+type MulOp struct {
+	Text    string
+	Start   strings.Position
+	End     strings.Position
+	Spacing *Spacing
+}
+
+// ParseMulOp - parse rule MulOp.
+func ParseMulOp(buffer common.Buffer[rune, strings.Position]) (*MulOp, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcMulOpRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MulOp{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		case *Spacing:
+			text = append(text, x.Text...)
+			result.Spacing = x
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcMulOpRule common.Combinator[rune, strings.Position, []any]
+
+// Spacing - result of rule:
+//
+//	Spacing <- [ \t\n]*
+type Spacing struct {
+	Text  string
+	Start strings.Position
+	End   strings.Position
+}
+
+// ParseSpacing - parse rule Spacing.
+func ParseSpacing(buffer common.Buffer[rune, strings.Position]) (*Spacing, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	children, err := calcSpacingRule(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Spacing{Start: start, End: buffer.Position()}
+	text := make([]byte, 0)
+
+	for _, child := range children {
+		switch x := child.(type) {
+		case string:
+			text = append(text, x...)
+		}
+	}
+
+	result.Text = string(text)
+
+	return result, nil
+}
+
+var calcSpacingRule common.Combinator[rune, strings.Position, []any]
+
+func init() {
+	calcExprRule = strings.Try(strings.Concat(0, calcChild(ParseSpacing), calcChild(ParseSum), strings.Cast(strings.NotFollowedBy("expected end of input", calcClass("expected any character", func(rune) bool { return true })), calcNothing[bool])))
+
+	calcSumRule = strings.Try(strings.Concat(0, calcChild(ParseProduct), calcFlatten(strings.Many(0, strings.Try(strings.Concat(0, calcChild(ParseAddOp), calcChild(ParseProduct)))))))
+
+	calcProductRule = strings.Try(strings.Concat(0, calcChild(ParseValue), calcFlatten(strings.Many(0, strings.Try(strings.Concat(0, calcChild(ParseMulOp), calcChild(ParseValue)))))))
+
+	calcValueRule = strings.Choice("expected Number / \"(\" Spacing Sum \")\" Spacing", calcChild(ParseNumber), strings.Try(strings.Concat(0, calcLiteral("expected \"(\"", "("), calcChild(ParseSpacing), calcChild(ParseSum), calcLiteral("expected \")\"", ")"), calcChild(ParseSpacing))))
+
+	calcNumberRule = strings.Try(strings.Concat(0, calcFlatten(strings.Some(0, "expected [0-9]+", calcClass("expected [0-9]", func(x rune) bool { return (x >= '0' && x <= '9') }))), calcChild(ParseSpacing)))
+
+	calcAddOpRule = strings.Try(strings.Concat(0, calcClass("expected [+\\-]", func(x rune) bool { return x == '+' || x == '-' }), calcChild(ParseSpacing)))
+
+	calcMulOpRule = strings.Try(strings.Concat(0, calcClass("expected [*/]", func(x rune) bool { return x == '*' || x == '/' }), calcChild(ParseSpacing)))
+
+	calcSpacingRule = calcFlatten(strings.Many(0, calcClass("expected [ \\t\\n]", func(x rune) bool { return x == ' ' || x == '\t' || x == '\n' })))
+}
+
+func calcLiteral(errMessage, text string) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(
+		strings.Try(strings.String(errMessage, text)),
+		func(x string) ([]any, error) {
+			return []any{x}, nil
+		},
+	)
+}
+
+func calcClass(errMessage string, f common.Condition[rune]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(
+		strings.Try(strings.Satisfy(errMessage, true, f)),
+		func(x rune) ([]any, error) {
+			return []any{string(x)}, nil
+		},
+	)
+}
+
+func calcChild[T any](c common.Combinator[rune, strings.Position, T]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(c, func(x T) ([]any, error) {
+		return []any{x}, nil
+	})
+}
+
+func calcFlatten(c common.Combinator[rune, strings.Position, [][]any]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(c, func(x [][]any) ([]any, error) {
+		result := make([]any, 0, len(x))
+		for _, items := range x {
+			result = append(result, items...)
+		}
+
+		return result, nil
+	})
+}
+
+func calcNothing[T any](T) ([]any, error) {
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package calc
+
+import (
+	"os"
+	"strconv"
+	gostrings "strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/grammar"
+	"github.com/okneniz/parsec/strings"
+)
+
+func eval(x *Sum) int {
+	result := product(x.Product[0])
+
+	for i, op := range x.AddOp {
+		if op.Text[0] == '+' {
+			result += product(x.Product[i+1])
+		} else {
+			result -= product(x.Product[i+1])
+		}
+	}
+
+	return result
+}
+
+func product(x *Product) int {
+	result := value(x.Value[0])
+
+	for i, op := range x.MulOp {
+		if op.Text[0] == '*' {
+			result *= value(x.Value[i+1])
+		} else {
+			result /= value(x.Value[i+1])
+		}
+	}
+
+	return result
+}
+
+func value(x *Value) int {
+	if x.Number != nil {
+		n, _ := strconv.Atoi(gostrings.TrimSpace(x.Number.Text))
+		return n
+	}
+
+	return eval(x.Sum)
+}
+
+func TestCalc(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]int{
+		"1":                   1,
+		" 2 + 3 * 4 ":         14,
+		"(2 + 3) * 4":         20,
+		"100 / (7 - 2) - 1":   19,
+		"((1))\n+\t((2)) * 3": 7,
+	}
+
+	for input, expected := range cases {
+		result, err := strings.ParseString(input, ParseExpr)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, eval(result.Sum), input)
+		assert.Equal(t, input, result.Text)
+	}
+
+	result, err := strings.ParseString("1 + 2", ParseSum)
+	assert.NoError(t, err)
+	assert.Equal(t, "1 + 2", result.Text)
+	assert.Equal(t, 0, result.Start.Index())
+	assert.Equal(t, 5, result.End.Index())
+	assert.Equal(t, "+ ", result.AddOp[0].Text)
+	assert.Equal(t, 4, result.Product[1].Start.Index())
+
+	_, err = strings.ParseString("1 +", ParseExpr)
+	assert.EqualError(t, err, "Parse error at line=0 column=2 index=2: expected end of input")
+
+	_, err = strings.ParseString("(1", ParseValue)
+	assert.Error(t, err)
+}
+
+func TestCalc_Generated(t *testing.T) {
+	t.Parallel()
+
+	text, err := os.ReadFile("calc.peg")
+	assert.NoError(t, err)
+
+	g, err := grammar.ParsePEG(string(text))
+	assert.NoError(t, err)
+
+	source, err := g.GenerateGo(grammar.GoOptions{
+		Package: "calc",
+		Prefix:  "calc",
+		Source:  "calc.peg",
+	})
+	assert.NoError(t, err)
+
+	generated, err := os.ReadFile("calc.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(source), string(generated), "run go generate to update calc.go")
+}
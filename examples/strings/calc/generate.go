@@ -0,0 +1,4 @@
+// Package calc - calculator parsed by code generated from calc.peg grammar.
+package calc
+
+//go:generate go run github.com/okneniz/parsec/cmd/parsecgen -in calc.peg
@@ -0,0 +1,212 @@
+package grammar
+
+import (
+	"fmt"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/okneniz/parsec/strings"
+)
+
+type (
+	// expression - node of grammar syntax tree.
+	expression interface {
+		fmt.Stringer
+	}
+
+	choice struct {
+		alternatives []expression
+	}
+
+	sequence struct {
+		items []expression
+	}
+
+	// repetition - match expression from min to max times,
+	// max is negative for unlimited repetitions.
+	repetition struct {
+		expression expression
+		min        int
+		max        int
+	}
+
+	predicate struct {
+		expression expression
+		negative   bool
+	}
+
+	literal struct {
+		text string
+	}
+
+	class struct {
+		ranges  []runeRange
+		negated bool
+		source  string
+	}
+
+	runeRange struct {
+		from rune
+		to   rune
+	}
+
+	anyRune struct{}
+
+	reference struct {
+		name     string
+		position strings.Position
+	}
+
+	// rule - named expression.
+	rule struct {
+		name       string
+		expression expression
+		position   strings.Position
+	}
+)
+
+func (x choice) String() string {
+	items := make([]string, len(x.alternatives))
+	for i, alt := range x.alternatives {
+		items[i] = alt.String()
+	}
+
+	return stdstrings.Join(items, " / ")
+}
+
+func (x sequence) String() string {
+	items := make([]string, len(x.items))
+
+	for i, item := range x.items {
+		if _, ok := item.(choice); ok {
+			items[i] = "(" + item.String() + ")"
+		} else {
+			items[i] = item.String()
+		}
+	}
+
+	return stdstrings.Join(items, " ")
+}
+
+func (x repetition) String() string {
+	var suffix string
+
+	switch {
+	case x.min == 0 && x.max == 1:
+		suffix = "?"
+	case x.min == 0 && x.max < 0:
+		suffix = "*"
+	case x.min == 1 && x.max < 0:
+		suffix = "+"
+	}
+
+	if _, ok := x.expression.(repetition); ok {
+		return "(" + x.expression.String() + ")" + suffix
+	}
+
+	return group(x.expression) + suffix
+}
+
+func (x predicate) String() string {
+	if x.negative {
+		return "!" + group(x.expression)
+	}
+
+	return "&" + group(x.expression)
+}
+
+func (x literal) String() string {
+	return strconv.Quote(x.text)
+}
+
+func (x class) String() string {
+	return x.source
+}
+
+func (x class) match(r rune) bool {
+	for _, rng := range x.ranges {
+		if r >= rng.from && r <= rng.to {
+			return !x.negated
+		}
+	}
+
+	return x.negated
+}
+
+func (anyRune) String() string {
+	return "."
+}
+
+func (x reference) String() string {
+	return x.name
+}
+
+func (x rule) String() string {
+	return x.name + " <- " + x.expression.String()
+}
+
+func group(x expression) string {
+	switch x.(type) {
+	case choice, sequence:
+		return "(" + x.String() + ")"
+	default:
+		return x.String()
+	}
+}
+
+// nullable - true if expression can succeed without consuming input.
+func nullable(x expression, rules map[string]bool) bool {
+	switch x := x.(type) {
+	case choice:
+		for _, alt := range x.alternatives {
+			if nullable(alt, rules) {
+				return true
+			}
+		}
+
+		return false
+	case sequence:
+		for _, item := range x.items {
+			if !nullable(item, rules) {
+				return false
+			}
+		}
+
+		return true
+	case repetition:
+		return x.min == 0 || nullable(x.expression, rules)
+	case predicate:
+		return true
+	case reference:
+		return rules[x.name]
+	default:
+		return false
+	}
+}
+
+// leftReferences - names of rules which can be called
+// by expression before consuming of input.
+func leftReferences(x expression, rules map[string]bool, result []string) []string {
+	switch x := x.(type) {
+	case choice:
+		for _, alt := range x.alternatives {
+			result = leftReferences(alt, rules, result)
+		}
+	case sequence:
+		for _, item := range x.items {
+			result = leftReferences(item, rules, result)
+
+			if !nullable(item, rules) {
+				break
+			}
+		}
+	case repetition:
+		result = leftReferences(x.expression, rules, result)
+	case predicate:
+		result = leftReferences(x.expression, rules, result)
+	case reference:
+		result = append(result, x.name)
+	}
+
+	return result
+}
@@ -0,0 +1,446 @@
+package grammar
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	stdstrings "strings"
+	"unicode"
+)
+
+// GoOptions - options of Go code generation.
+type GoOptions struct {
+	// Package - name of package of generated file.
+	Package string
+	// Prefix - prefix of unexported identifiers of generated file,
+	// allows to generate several grammars in one package.
+	Prefix string
+	// Source - name of grammar file, written to header of generated file.
+	Source string
+}
+
+// GenerateGo - generate Go source of parser built from strings combinators.
+// For each rule it generates struct with text, span and children
+// and ParseRule combinator which returns it.
+func (g *Grammar) GenerateGo(options GoOptions) ([]byte, error) {
+	if options.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	gen := &generator{
+		grammar: g,
+		prefix:  options.Prefix,
+		types:   make(map[string]string, len(g.names)),
+		out:     new(bytes.Buffer),
+	}
+
+	if gen.prefix == "" {
+		gen.prefix = "grammar"
+	}
+
+	used := make(map[string]struct{}, len(g.names))
+	for i, name := range g.names {
+		typeName := exportedName(name)
+		if typeName == "" {
+			typeName = fmt.Sprintf("Rule%d", i+1)
+		}
+
+		for _, exists := used[typeName]; exists; _, exists = used[typeName] {
+			typeName += "_"
+		}
+
+		used[typeName] = struct{}{}
+		gen.types[name] = typeName
+	}
+
+	if err := gen.generate(options); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(gen.out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return source, nil
+}
+
+type generator struct {
+	grammar *Grammar
+	prefix  string
+	types   map[string]string
+	out     *bytes.Buffer
+}
+
+func (gen *generator) printf(format string, args ...any) {
+	fmt.Fprintf(gen.out, format, args...)
+}
+
+func (gen *generator) generate(options GoOptions) error {
+	source := ""
+	if options.Source != "" {
+		source = " from " + options.Source
+	}
+
+	gen.printf("// Code generated by parsecgen%s. DO NOT EDIT.\n\n", source)
+	gen.printf("package %s\n\n", options.Package)
+	gen.printf("import (\n")
+	gen.printf("\t%q\n", "github.com/okneniz/parsec/common")
+	gen.printf("\t%q\n", "github.com/okneniz/parsec/strings")
+	gen.printf(")\n\n")
+
+	for _, name := range gen.grammar.names {
+		gen.rule(gen.grammar.rules[name])
+	}
+
+	gen.printf("func init() {\n")
+
+	for i, name := range gen.grammar.names {
+		body, err := gen.expression(gen.grammar.rules[name].expression)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", name, err)
+		}
+
+		if i > 0 {
+			gen.printf("\n")
+		}
+
+		gen.printf("%s = %s\n", gen.ruleVar(name), body)
+	}
+
+	gen.printf("}\n\n")
+	gen.helpers()
+
+	return nil
+}
+
+func (gen *generator) ruleVar(name string) string {
+	return gen.prefix + gen.types[name] + "Rule"
+}
+
+func (gen *generator) rule(r rule) {
+	typeName := gen.types[r.name]
+	fields := gen.fields(r.expression)
+
+	gen.printf("// %s - result of rule:\n//\n//\t%s\n", typeName, r.String())
+	gen.printf("type %s struct {\n", typeName)
+	gen.printf("Text string\n")
+	gen.printf("Start strings.Position\n")
+	gen.printf("End strings.Position\n")
+
+	for _, f := range fields {
+		if f.many {
+			gen.printf("%s []*%s\n", f.name, gen.types[f.rule])
+		} else {
+			gen.printf("%s *%s\n", f.name, gen.types[f.rule])
+		}
+	}
+
+	gen.printf("}\n\n")
+
+	gen.printf("// Parse%s - parse rule %s.\n", typeName, r.name)
+	gen.printf(
+		"func Parse%s(buffer common.Buffer[rune, strings.Position]) (*%s, common.Error[strings.Position]) {\n",
+		typeName,
+		typeName,
+	)
+	gen.printf("start := buffer.Position()\n\n")
+	gen.printf("children, err := %s(buffer)\n", gen.ruleVar(r.name))
+	gen.printf("if err != nil {\nreturn nil, err\n}\n\n")
+	gen.printf("result := &%s{Start: start, End: buffer.Position()}\n", typeName)
+	gen.printf("text := make([]byte, 0)\n\n")
+	gen.printf("for _, child := range children {\n")
+	gen.printf("switch x := child.(type) {\n")
+	gen.printf("case string:\ntext = append(text, x...)\n")
+
+	for _, f := range fields {
+		gen.printf("case *%s:\n", gen.types[f.rule])
+		gen.printf("text = append(text, x.Text...)\n")
+
+		if f.many {
+			gen.printf("result.%s = append(result.%s, x)\n", f.name, f.name)
+		} else {
+			gen.printf("result.%s = x\n", f.name)
+		}
+	}
+
+	gen.printf("}\n}\n\n")
+	gen.printf("result.Text = string(text)\n\n")
+	gen.printf("return result, nil\n}\n\n")
+	gen.printf("var %s common.Combinator[rune, strings.Position, []any]\n\n", gen.ruleVar(r.name))
+}
+
+type field struct {
+	name string
+	rule string
+	many bool
+}
+
+// fields - fields of rule struct for referenced rules
+// in order of the first reference.
+func (gen *generator) fields(x expression) []field {
+	counts := make(map[string]int)
+	order := make([]string, 0)
+
+	var walk func(x expression, many bool)
+	walk = func(x expression, many bool) {
+		switch x := x.(type) {
+		case choice:
+			before := make(map[string]int, len(counts))
+			for k, v := range counts {
+				before[k] = v
+			}
+
+			after := make(map[string]int, len(counts))
+
+			for _, alt := range x.alternatives {
+				for k, v := range before {
+					counts[k] = v
+				}
+
+				walk(alt, many)
+
+				for k, v := range counts {
+					after[k] = max(after[k], v)
+				}
+			}
+
+			for k, v := range after {
+				counts[k] = v
+			}
+		case sequence:
+			for _, item := range x.items {
+				walk(item, many)
+			}
+		case repetition:
+			walk(x.expression, many || x.max != 1)
+		case reference:
+			if _, exists := counts[x.name]; !exists {
+				order = append(order, x.name)
+			}
+
+			if many {
+				counts[x.name] += 2
+			} else {
+				counts[x.name]++
+			}
+		}
+	}
+
+	walk(x, false)
+
+	result := make([]field, 0, len(order))
+	for _, name := range order {
+		fieldName := gen.types[name]
+
+		switch fieldName {
+		case "Text", "Start", "End":
+			fieldName += "Node"
+		}
+
+		result = append(result, field{
+			name: fieldName,
+			rule: name,
+			many: counts[name] > 1,
+		})
+	}
+
+	return result
+}
+
+// expression - generate combinator of expression, which returns
+// consumed text and results of rules. Terminals and sequences are wrapped
+// by Try, so every generated combinator keeps position if it fails
+// and choices and repetitions don't need it.
+func (gen *generator) expression(x expression) (string, error) {
+	switch x := x.(type) {
+	case choice:
+		items := make([]string, len(x.alternatives))
+
+		for i, alt := range x.alternatives {
+			item, err := gen.expression(alt)
+			if err != nil {
+				return "", err
+			}
+
+			items[i] = item
+		}
+
+		return fmt.Sprintf(
+			"strings.Choice(%q, %s)",
+			"expected "+x.String(),
+			stdstrings.Join(items, ", "),
+		), nil
+	case sequence:
+		items := make([]string, len(x.items))
+
+		for i, item := range x.items {
+			code, err := gen.expression(item)
+			if err != nil {
+				return "", err
+			}
+
+			items[i] = code
+		}
+
+		return fmt.Sprintf("strings.Try(strings.Concat(0, %s))", stdstrings.Join(items, ", ")), nil
+	case repetition:
+		if x.max != 1 && nullable(x.expression, gen.grammar.nullable) {
+			return "", fmt.Errorf("repetition of expression which can match empty input: %s", x)
+		}
+
+		body, err := gen.expression(x.expression)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case x.max == 1:
+			return fmt.Sprintf("strings.Optional(%s, nil)", body), nil
+		case x.min == 0:
+			return fmt.Sprintf("%sFlatten(strings.Many(0, %s))", gen.prefix, body), nil
+		default:
+			return fmt.Sprintf(
+				"%sFlatten(strings.Some(0, %q, %s))",
+				gen.prefix,
+				"expected "+x.String(),
+				body,
+			), nil
+		}
+	case predicate:
+		body, err := gen.expression(x.expression)
+		if err != nil {
+			return "", err
+		}
+
+		if x.negative {
+			message := "unexpected " + group(x.expression)
+			if _, ok := x.expression.(anyRune); ok {
+				message = "expected end of input"
+			}
+
+			return fmt.Sprintf(
+				"strings.Cast(strings.NotFollowedBy(%q, %s), %sNothing[bool])",
+				message,
+				body,
+				gen.prefix,
+			), nil
+		}
+
+		return fmt.Sprintf("strings.Cast(strings.LookAhead(%s), %sNothing[[]any])", body, gen.prefix), nil
+	case literal:
+		return fmt.Sprintf("%sLiteral(%q, %q)", gen.prefix, "expected "+x.String(), x.text), nil
+	case class:
+		return fmt.Sprintf("%sClass(%q, %s)", gen.prefix, "expected "+x.String(), classCondition(x)), nil
+	case anyRune:
+		return fmt.Sprintf("%sClass(%q, func(rune) bool { return true })", gen.prefix, "expected any character"), nil
+	case reference:
+		return fmt.Sprintf("%sChild(Parse%s)", gen.prefix, gen.types[x.name]), nil
+	default:
+		return "", fmt.Errorf("unknown expression %T", x)
+	}
+}
+
+func classCondition(x class) string {
+	conditions := make([]string, len(x.ranges))
+
+	for i, rng := range x.ranges {
+		if rng.from == rng.to {
+			conditions[i] = "x == " + strconv.QuoteRune(rng.from)
+		} else {
+			conditions[i] = fmt.Sprintf(
+				"(x >= %s && x <= %s)",
+				strconv.QuoteRune(rng.from),
+				strconv.QuoteRune(rng.to),
+			)
+		}
+	}
+
+	condition := stdstrings.Join(conditions, " || ")
+
+	switch {
+	case len(conditions) == 0 && x.negated:
+		condition = "true"
+	case len(conditions) == 0:
+		condition = "false"
+	case x.negated:
+		condition = "!(" + condition + ")"
+	}
+
+	return "func(x rune) bool { return " + condition + " }"
+}
+
+func (gen *generator) helpers() {
+	p := gen.prefix
+
+	gen.printf(`func %sLiteral(errMessage, text string) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(
+		strings.Try(strings.String(errMessage, text)),
+		func(x string) ([]any, error) {
+			return []any{x}, nil
+		},
+	)
+}
+
+`, p)
+
+	gen.printf(`func %sClass(errMessage string, f common.Condition[rune]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(
+		strings.Try(strings.Satisfy(errMessage, true, f)),
+		func(x rune) ([]any, error) {
+			return []any{string(x)}, nil
+		},
+	)
+}
+
+`, p)
+
+	gen.printf(`func %sChild[T any](c common.Combinator[rune, strings.Position, T]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(c, func(x T) ([]any, error) {
+		return []any{x}, nil
+	})
+}
+
+`, p)
+
+	gen.printf(`func %sFlatten(c common.Combinator[rune, strings.Position, [][]any]) common.Combinator[rune, strings.Position, []any] {
+	return strings.Cast(c, func(x [][]any) ([]any, error) {
+		result := make([]any, 0, len(x))
+		for _, items := range x {
+			result = append(result, items...)
+		}
+
+		return result, nil
+	})
+}
+
+`, p)
+
+	gen.printf(`func %sNothing[T any](T) ([]any, error) {
+	return nil, nil
+}
+`, p)
+}
+
+// exportedName - convert rule name to exported Go identifier,
+// for example number_list -> NumberList.
+func exportedName(name string) string {
+	parts := stdstrings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+
+	result := new(stdstrings.Builder)
+
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		result.WriteString(string(runes))
+	}
+
+	if result.Len() > 0 && unicode.IsDigit([]rune(result.String())[0]) {
+		return "Rule" + result.String()
+	}
+
+	return result.String()
+}
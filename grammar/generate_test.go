@@ -0,0 +1,82 @@
+package grammar
+
+import (
+	stdstrings "strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("types and fields", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := ParseEBNF(`
+			key_value = key, "=", (value | key), { ",", value } ;
+			key       = letter+ ;
+			value     = [ "-" ], digit+ ;
+			letter    = "a" | "b" ;
+			digit     = "0" | "1" ;
+			text      = letter ;
+			pair      = text, text? ;
+			single    = text ;
+		`)
+		assert.NoError(t, err)
+
+		source, err := g.GenerateGo(GoOptions{Package: "kv", Source: "kv.ebnf"})
+		assert.NoError(t, err)
+
+		code := string(source)
+		assert.True(t, stdstrings.HasPrefix(code, "// Code generated by parsecgen from kv.ebnf. DO NOT EDIT.\n\npackage kv\n"))
+
+		for _, x := range []string{
+			"type KeyValue struct {",
+			"\tKey   []*Key\n",
+			"\tValue []*Value\n",
+			"\tLetter []*Letter\n",
+			"\tDigit []*Digit\n",
+			"\tLetter *Letter\n",
+			"\tTextNode []*Text\n",
+			"type Single struct {\n\tText     string\n\tStart    strings.Position\n\tEnd      strings.Position\n\tTextNode *Text\n}",
+			"func ParseKeyValue(buffer common.Buffer[rune, strings.Position]) (*KeyValue, common.Error[strings.Position]) {",
+			"var grammarKeyValueRule common.Combinator[rune, strings.Position, []any]",
+			"func grammarFlatten(",
+		} {
+			assert.Contains(t, code, x)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := ParsePEG(`A <- ('a'?)*`)
+		assert.NoError(t, err)
+
+		_, err = g.GenerateGo(GoOptions{Package: "a"})
+		assert.EqualError(t, err, `rule A: repetition of expression which can match empty input: ("a"?)*`)
+
+		_, err = g.GenerateGo(GoOptions{})
+		assert.EqualError(t, err, "package name is required")
+	})
+
+	t.Run("names", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "NumberList", exportedName("number_list"))
+		assert.Equal(t, "DigitExcludingZero", exportedName("digit-excluding-zero"))
+		assert.Equal(t, "Rule2x", exportedName("2x"))
+		assert.Equal(t, "", exportedName("_"))
+
+		g, err := ParsePEG("_ <- ' '*\nA <- _ 'a' a\na <- 'b'")
+		assert.NoError(t, err)
+
+		source, err := g.GenerateGo(GoOptions{Package: "a", Prefix: "x"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(source), "type Rule1 struct {")
+		assert.Contains(t, string(source), "type A struct {")
+		assert.Contains(t, string(source), "type A_ struct {")
+		assert.Contains(t, string(source), "var xRule1Rule common.Combinator")
+	})
+}
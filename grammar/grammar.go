@@ -0,0 +1,181 @@
+package grammar
+
+import (
+	"fmt"
+	stdstrings "strings"
+
+	"github.com/okneniz/parsec/lexer"
+)
+
+// Grammar - set of named rules loaded from grammar text.
+type Grammar struct {
+	rules    map[string]rule
+	names    []string
+	nullable map[string]bool
+}
+
+// ParsePEG - load grammar in PEG notation:
+//
+//	# comment
+//	Sum    <- Number (("+" / "-") Number)*
+//	Number <- [0-9]+ !.
+//
+// Supports ordered choice (/), sequences, groups, optional (?),
+// repetitions (* and +), predicates (& and !), literals in single or double quotes,
+// character classes ([a-z], [^0-9]) and any character (.).
+func ParsePEG(text string) (*Grammar, error) {
+	return parse(pegLexer, syntax{}, text)
+}
+
+// ParseEBNF - load grammar in EBNF notation:
+//
+//	(* comment *)
+//	sum    = number, { ("+" | "-"), number } ;
+//	number = digit, { digit } ;
+//	digit  = "0" | "1" | "2" | "3" | "4" | "5" | "6" | "7" | "8" | "9" ;
+//
+// Supports alternatives (|), sequences (commas are optional), groups,
+// optional ([...]), repetitions ({...}), postfix ?, * and +
+// and literals in single or double quotes. Rules are defined by = or ::=
+// and ended by ; or a dot. Alternatives are tried in order like in PEG.
+func ParseEBNF(text string) (*Grammar, error) {
+	return parse(ebnfLexer, syntax{ebnf: true}, text)
+}
+
+func parse(l *lexer.Lexer[kind], s syntax, text string) (*Grammar, error) {
+	rules, err := lexer.Parse(l, text, s.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGrammar(rules)
+}
+
+func newGrammar(rules []rule) (*Grammar, error) {
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("grammar without rules")
+	}
+
+	g := &Grammar{
+		rules: make(map[string]rule, len(rules)),
+		names: make([]string, 0, len(rules)),
+	}
+
+	for _, r := range rules {
+		if _, exists := g.rules[r.name]; exists {
+			return nil, fmt.Errorf("rule %s at %v: duplicate definition", r.name, r.position)
+		}
+
+		g.rules[r.name] = r
+		g.names = append(g.names, r.name)
+	}
+
+	for _, name := range g.names {
+		if err := g.checkReferences(g.rules[name].expression); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", name, err)
+		}
+	}
+
+	g.nullable = g.nullableRules()
+
+	for _, name := range g.names {
+		if path := g.leftRecursion(name, []string{name}); path != nil {
+			return nil, fmt.Errorf(
+				"rule %s: left recursion %s",
+				name,
+				stdstrings.Join(path, " -> "),
+			)
+		}
+	}
+
+	return g, nil
+}
+
+// Rules - names of rules in order of definition.
+func (g *Grammar) Rules() []string {
+	return append([]string(nil), g.names...)
+}
+
+// String - return grammar in PEG notation.
+func (g *Grammar) String() string {
+	lines := make([]string, len(g.names))
+	for i, name := range g.names {
+		lines[i] = g.rules[name].String()
+	}
+
+	return stdstrings.Join(lines, "\n")
+}
+
+func (g *Grammar) checkReferences(x expression) error {
+	switch x := x.(type) {
+	case choice:
+		for _, alt := range x.alternatives {
+			if err := g.checkReferences(alt); err != nil {
+				return err
+			}
+		}
+	case sequence:
+		for _, item := range x.items {
+			if err := g.checkReferences(item); err != nil {
+				return err
+			}
+		}
+	case repetition:
+		return g.checkReferences(x.expression)
+	case predicate:
+		return g.checkReferences(x.expression)
+	case reference:
+		if _, exists := g.rules[x.name]; !exists {
+			return fmt.Errorf("undefined rule %s at %v", x.name, x.position)
+		}
+	}
+
+	return nil
+}
+
+func (g *Grammar) nullableRules() map[string]bool {
+	result := make(map[string]bool, len(g.names))
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, name := range g.names {
+			if !result[name] && nullable(g.rules[name].expression, result) {
+				result[name] = true
+				changed = true
+			}
+		}
+	}
+
+	return result
+}
+
+// leftRecursion - returns path of calls from rule to itself
+// without consuming of input, nil if rule is not left recursive.
+func (g *Grammar) leftRecursion(name string, path []string) []string {
+	current := path[len(path)-1]
+
+	for _, ref := range leftReferences(g.rules[current].expression, g.nullable, nil) {
+		if ref == name {
+			return append(path, ref)
+		}
+
+		visited := false
+		for _, x := range path {
+			if x == ref {
+				visited = true
+				break
+			}
+		}
+
+		if visited {
+			continue
+		}
+
+		if result := g.leftRecursion(name, append(path, ref)); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
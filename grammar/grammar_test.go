@@ -0,0 +1,123 @@
+package grammar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePEG(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rules", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := ParsePEG(`
+			# arithmetic
+			Expr   <- Term (('+' / "-") Term)*
+			Term   <- Factor ([*/] Factor)*
+			Factor <- Number / '(' Expr ')'
+			Number <- [0-9]+ ('.' [0-9]+)? !.
+			Any    <- . &"\n" [^\]a-z\-]
+		`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Expr", "Term", "Factor", "Number", "Any"}, g.Rules())
+		assert.Equal(
+			t,
+			`Expr <- Term (("+" / "-") Term)*
+Term <- Factor ([*/] Factor)*
+Factor <- Number / "(" Expr ")"
+Number <- [0-9]+ ("." [0-9]+)? !.
+Any <- . &"\n" [^\]a-z\-]`,
+			g.String(),
+		)
+
+		class := g.rules["Any"].expression.(sequence).items[2].(class)
+		assert.Equal(t, []runeRange{{']', ']'}, {'a', 'z'}, {'-', '-'}}, class.ranges)
+		assert.True(t, class.negated)
+	})
+
+	t.Run("escapes", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := ParsePEG(`A <- '\'\t\x41é' [Ѐ-ӿ]`)
+		assert.NoError(t, err)
+		assert.Equal(t, literal{text: "'\tAé"}, g.rules["A"].expression.(sequence).items[0])
+		assert.Equal(
+			t,
+			[]runeRange{{0x400, 0x4ff}},
+			g.rules["A"].expression.(sequence).items[1].(class).ranges,
+		)
+	})
+
+	t.Run("syntax errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParsePEG(`A <- ("a" / "b"`)
+		assert.EqualError(t, err, "Parse error at token=6 span=line=0 column=12 index=12: expected )")
+
+		_, err = ParsePEG(`A <- `)
+		assert.EqualError(t, err, "Parse error at token=2 span=line=0 column=2 index=2: expected expression")
+
+		_, err = ParsePEG(`A "a"`)
+		assert.EqualError(t, err, "Parse error at token=1 span=line=0 column=2 index=2: expected <- after rule name")
+
+		_, err = ParsePEG(`A <- [z-a]`)
+		assert.EqualError(t, err, "Parse error at token=2 span=line=0 column=5 index=5: invalid range z-a in class [z-a]")
+
+		_, err = ParsePEG(`A <- '\u12'`)
+		assert.EqualError(t, err, `Parse error at token=2 span=line=0 column=5 index=5: invalid escape sequence \u12`)
+
+		_, err = ParsePEG(`A <- @`)
+		assert.EqualError(t, err, "Parse error at line=0 column=5 index=5: unexpected '@'")
+
+		_, err = ParsePEG(``)
+		assert.EqualError(t, err, "grammar without rules")
+	})
+
+	t.Run("semantic errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParsePEG("A <- 'a'\nA <- 'b'")
+		assert.EqualError(t, err, "rule A at line=1 column=0 index=9: duplicate definition")
+
+		_, err = ParsePEG("A <- 'a' B")
+		assert.EqualError(t, err, "rule A: undefined rule B at line=0 column=9 index=9")
+
+		_, err = ParsePEG("A <- B 'a' / 'b'\nB <- 'c'? A")
+		assert.EqualError(t, err, "rule A: left recursion A -> B -> A")
+
+		_, err = ParsePEG("A <- 'a' A / !'b' A*")
+		assert.EqualError(t, err, "rule A: left recursion A -> A")
+	})
+}
+
+func TestParseEBNF(t *testing.T) {
+	t.Parallel()
+
+	g, err := ParseEBNF(`
+		(* list of numbers *)
+		list   = "[", [ number, { ",", number } ], "]" ;
+		number ::= digit+ ('.' digit digit*)? .
+		digit  = "0" | "1" | "2" | "3" | "4" | "5" | "6" | "7" | "8" | "9";
+	`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"list", "number", "digit"}, g.Rules())
+	assert.Equal(
+		t,
+		`list <- "[" (number ("," number)*)? "]"
+number <- digit+ ("." digit digit*)?
+digit <- "0" / "1" / "2" / "3" / "4" / "5" / "6" / "7" / "8" / "9"`,
+		g.String(),
+	)
+
+	g, err = ParseEBNF(`a = [ { "x" } ] ;`)
+	assert.NoError(t, err)
+	assert.Equal(t, `a <- ("x"*)?`, g.String())
+
+	_, err = ParseEBNF(`a = "x"`)
+	assert.EqualError(t, err, "Parse error at token=3 span=line=0 column=4 index=4: expected ; or . at the end of rule")
+
+	_, err = ParseEBNF(`a = { "x" ;`)
+	assert.EqualError(t, err, "Parse error at token=4 span=line=0 column=10 index=10: expected }")
+}
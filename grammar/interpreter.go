@@ -0,0 +1,451 @@
+package grammar
+
+import (
+	"fmt"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+)
+
+// matcher - compiled expression, returns nodes of matched rules.
+// Keeps buffer position if it fails.
+type matcher func(s *state) ([]*Node, bool)
+
+// state - state of one parsing, tracks the farthest failure to report errors.
+type state struct {
+	buffer   common.Buffer[rune, strings.Position]
+	text     []rune
+	base     int
+	farthest strings.Position
+	expected []string
+	silent   int
+	err      common.Error[strings.Position]
+}
+
+func newState(buffer common.Buffer[rune, strings.Position]) *state {
+	pos := buffer.Position()
+
+	return &state{
+		buffer:   buffer,
+		base:     pos.Index(),
+		farthest: pos,
+	}
+}
+
+var (
+	_ common.Buffer[rune, strings.Position]  = new(state)
+	_ common.Wrapper[rune, strings.Position] = new(state)
+)
+
+// Read - read rune from buffer of state, used by tracing only.
+func (s *state) Read(greedy bool) (rune, error) {
+	return s.buffer.Read(greedy)
+}
+
+// Seek - change position of buffer of state.
+func (s *state) Seek(pos strings.Position) error {
+	return s.buffer.Seek(pos)
+}
+
+// Position - position of buffer of state.
+func (s *state) Position() strings.Position {
+	return s.buffer.Position()
+}
+
+// IsEOF - true if buffer of state ended.
+func (s *state) IsEOF() bool {
+	return s.buffer.IsEOF()
+}
+
+// Unwrap - buffer of state, used by tracing and debugging.
+func (s *state) Unwrap() common.Buffer[rune, strings.Position] {
+	return s.buffer
+}
+
+func (s *state) read() (rune, bool) {
+	pos := s.buffer.Position()
+
+	x, err := s.buffer.Read(true)
+	if err != nil {
+		return 0, false
+	}
+
+	if pos.Index()-s.base == len(s.text) {
+		s.text = append(s.text, x)
+	}
+
+	return x, true
+}
+
+func (s *state) seek(pos strings.Position) {
+	// positions are taken from buffer before reading, so seek can't fail
+	_ = s.buffer.Seek(pos)
+}
+
+func (s *state) expect(pos strings.Position, description string) {
+	if s.silent > 0 || pos.Index() < s.farthest.Index() {
+		return
+	}
+
+	if pos.Index() > s.farthest.Index() {
+		s.farthest = pos
+		s.expected = s.expected[:0]
+	}
+
+	for _, x := range s.expected {
+		if x == description {
+			return
+		}
+	}
+
+	s.expected = append(s.expected, description)
+}
+
+func (s *state) slice(from, to strings.Position) string {
+	return string(s.text[from.Index()-s.base : to.Index()-s.base])
+}
+
+func (s *state) error() common.Error[strings.Position] {
+	switch len(s.expected) {
+	case 0:
+		return common.NewParseError(s.farthest, "unexpected input")
+	case 1:
+		return common.NewParseError(s.farthest, "expected "+s.expected[0])
+	default:
+		last := len(s.expected) - 1
+
+		return common.NewParseError(
+			s.farthest,
+			"expected "+stdstrings.Join(s.expected[:last], ", ")+" or "+s.expected[last],
+		)
+	}
+}
+
+// Compile - make combinator for rule named start, which returns parse tree.
+// Semantic actions are called for matched rules.
+// Returns error if rules of start or actions are not defined.
+func (g *Grammar) Compile(
+	start string,
+	actions Actions,
+) (common.Combinator[rune, strings.Position, *Node], error) {
+	parse, err := g.compile(start, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(buffer common.Buffer[rune, strings.Position]) (*Node, common.Error[strings.Position]) {
+		node, s := parse(buffer)
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		if node == nil {
+			return nil, s.error()
+		}
+
+		return node, nil
+	}, nil
+}
+
+// Parse - parse whole text by rule named start.
+func (g *Grammar) Parse(start string, text string, actions Actions) (*Node, error) {
+	parse, err := g.compile(start, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := strings.Buffer([]rune(text))
+
+	node, s := parse(buffer)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if node == nil {
+		return nil, s.error()
+	}
+
+	if !buffer.IsEOF() {
+		pos := buffer.Position()
+
+		if s.farthest.Index() >= pos.Index() {
+			s.expect(pos, "end of input")
+			return nil, s.error()
+		}
+
+		x, _ := buffer.Read(false)
+		return nil, common.NewParseError(pos, fmt.Sprintf("unexpected %q", x))
+	}
+
+	return node, nil
+}
+
+func (g *Grammar) compile(
+	start string,
+	actions Actions,
+) (func(common.Buffer[rune, strings.Position]) (*Node, *state), error) {
+	if _, exists := g.rules[start]; !exists {
+		return nil, fmt.Errorf("undefined rule %s", start)
+	}
+
+	for name := range actions {
+		if _, exists := g.rules[name]; !exists {
+			return nil, fmt.Errorf("action for undefined rule %s", name)
+		}
+	}
+
+	rules := make(map[string]matcher, len(g.rules))
+	for _, name := range g.names {
+		rules[name] = g.compileRule(g.rules[name], rules, actions[name])
+	}
+
+	parse := rules[start]
+
+	return func(buffer common.Buffer[rune, strings.Position]) (*Node, *state) {
+		s := newState(buffer)
+
+		nodes, ok := parse(s)
+		if !ok {
+			return nil, s
+		}
+
+		return nodes[0], s
+	}, nil
+}
+
+func (g *Grammar) compileRule(r rule, rules map[string]matcher, action Action) matcher {
+	body := compileExpression(r.expression, rules)
+	message := "expected " + r.name
+
+	// rules are named combinators, so they can be traced and debugged (see common.EnableTracing)
+	named := common.Named(r.name, func(
+		buffer common.Buffer[rune, strings.Position],
+	) ([]*Node, common.Error[strings.Position]) {
+		s := buffer.(*state)
+		start := s.buffer.Position()
+
+		children, ok := body(s)
+		if !ok {
+			return nil, common.NewParseError(start, message)
+		}
+
+		end := s.buffer.Position()
+
+		node := &Node{
+			Name:     r.name,
+			Text:     s.slice(start, end),
+			Start:    start,
+			End:      end,
+			Children: children,
+		}
+
+		if action != nil {
+			value, err := action(node)
+			if err != nil {
+				s.err = common.NewParseError(start, err.Error())
+				return nil, s.err
+			}
+
+			node.Value = value
+		}
+
+		return []*Node{node}, nil
+	})
+
+	return func(s *state) ([]*Node, bool) {
+		nodes, err := named(s)
+		return nodes, err == nil
+	}
+}
+
+func compileExpression(x expression, rules map[string]matcher) matcher {
+	switch x := x.(type) {
+	case choice:
+		return compileChoice(x, rules)
+	case sequence:
+		return compileSequence(x, rules)
+	case repetition:
+		return compileRepetition(x, rules)
+	case predicate:
+		return compilePredicate(x, rules)
+	case literal:
+		return compileLiteral(x)
+	case class:
+		return compileClass(x)
+	case anyRune:
+		return compileAny()
+	case reference:
+		// rules are compiled lazy to support recursion
+		return func(s *state) ([]*Node, bool) {
+			return rules[x.name](s)
+		}
+	default:
+		panic(fmt.Sprintf("unknown expression %T", x))
+	}
+}
+
+func compileChoice(x choice, rules map[string]matcher) matcher {
+	alternatives := make([]matcher, len(x.alternatives))
+	for i, alt := range x.alternatives {
+		alternatives[i] = compileExpression(alt, rules)
+	}
+
+	return func(s *state) ([]*Node, bool) {
+		for _, alt := range alternatives {
+			nodes, ok := alt(s)
+			if ok {
+				return nodes, true
+			}
+
+			if s.err != nil {
+				return nil, false
+			}
+		}
+
+		return nil, false
+	}
+}
+
+func compileSequence(x sequence, rules map[string]matcher) matcher {
+	items := make([]matcher, len(x.items))
+	for i, item := range x.items {
+		items[i] = compileExpression(item, rules)
+	}
+
+	return func(s *state) ([]*Node, bool) {
+		start := s.buffer.Position()
+
+		var result []*Node
+
+		for _, item := range items {
+			nodes, ok := item(s)
+			if !ok {
+				s.seek(start)
+				return nil, false
+			}
+
+			result = append(result, nodes...)
+		}
+
+		return result, true
+	}
+}
+
+func compileRepetition(x repetition, rules map[string]matcher) matcher {
+	body := compileExpression(x.expression, rules)
+
+	return func(s *state) ([]*Node, bool) {
+		start := s.buffer.Position()
+
+		var result []*Node
+
+		for count := 0; x.max < 0 || count < x.max; count++ {
+			pos := s.buffer.Position()
+
+			nodes, ok := body(s)
+			if s.err != nil {
+				return nil, false
+			}
+
+			if !ok {
+				if count < x.min {
+					s.seek(start)
+					return nil, false
+				}
+
+				break
+			}
+
+			result = append(result, nodes...)
+
+			// stop on empty match to avoid infinite loop
+			if s.buffer.Position().Index() == pos.Index() {
+				break
+			}
+		}
+
+		return result, true
+	}
+}
+
+func compilePredicate(x predicate, rules map[string]matcher) matcher {
+	body := compileExpression(x.expression, rules)
+	description := x.String()[1:]
+
+	if _, ok := x.expression.(anyRune); ok && x.negative {
+		description = "end of input"
+	} else if x.negative {
+		description = "not " + description
+	}
+
+	return func(s *state) ([]*Node, bool) {
+		start := s.buffer.Position()
+
+		s.silent++
+		_, ok := body(s)
+		s.silent--
+
+		if s.err != nil {
+			return nil, false
+		}
+
+		s.seek(start)
+
+		if ok == x.negative {
+			s.expect(start, description)
+			return nil, false
+		}
+
+		return nil, true
+	}
+}
+
+func compileLiteral(x literal) matcher {
+	data := []rune(x.text)
+	description := strconv.Quote(x.text)
+
+	return func(s *state) ([]*Node, bool) {
+		start := s.buffer.Position()
+
+		for _, r := range data {
+			if c, ok := s.read(); !ok || c != r {
+				s.seek(start)
+				s.expect(start, description)
+
+				return nil, false
+			}
+		}
+
+		return nil, true
+	}
+}
+
+func compileClass(x class) matcher {
+	description := x.String()
+
+	return func(s *state) ([]*Node, bool) {
+		start := s.buffer.Position()
+
+		if c, ok := s.read(); !ok || !x.match(c) {
+			s.seek(start)
+			s.expect(start, description)
+
+			return nil, false
+		}
+
+		return nil, true
+	}
+}
+
+func compileAny() matcher {
+	return func(s *state) ([]*Node, bool) {
+		if _, ok := s.read(); !ok {
+			s.expect(s.buffer.Position(), "any character")
+			return nil, false
+		}
+
+		return nil, true
+	}
+}
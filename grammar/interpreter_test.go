@@ -0,0 +1,202 @@
+package grammar
+
+import (
+	"errors"
+	"strconv"
+	stdstrings "strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+)
+
+const calculator = `
+	Expr    <- _ Sum !.
+	Sum     <- Product (AddOp Product)*
+	Product <- Value (MulOp Value)*
+	Value   <- Number / '(' _ Sum ')' _
+	Number  <- [0-9]+ _
+	AddOp   <- [+\-] _
+	MulOp   <- [*/] _
+	_       <- [ \t\n]*
+`
+
+func calculatorActions() Actions {
+	fold := func(node *Node) (any, error) {
+		var (
+			result int
+			op     string
+		)
+
+		for i, child := range node.Children {
+			switch child.Name {
+			case "AddOp", "MulOp":
+				op = child.Text[:1]
+				continue
+			case "_":
+				continue
+			}
+
+			x := child.Value.(int)
+
+			switch {
+			case i == 0:
+				result = x
+			case op == "+":
+				result += x
+			case op == "-":
+				result -= x
+			case op == "*":
+				result *= x
+			case x == 0:
+				return nil, errors.New("division by zero")
+			default:
+				result /= x
+			}
+		}
+
+		return result, nil
+	}
+
+	return Actions{
+		"Expr": func(node *Node) (any, error) {
+			return node.Child("Sum").Value, nil
+		},
+		"Sum":     fold,
+		"Product": fold,
+		"Value": func(node *Node) (any, error) {
+			if x := node.Child("Number"); x != nil {
+				return x.Value, nil
+			}
+
+			return node.Child("Sum").Value, nil
+		},
+		"Number": func(node *Node) (any, error) {
+			return strconv.Atoi(stdstrings.TrimSpace(node.Text))
+		},
+	}
+}
+
+func TestInterpreter(t *testing.T) {
+	t.Parallel()
+
+	g, err := ParsePEG(calculator)
+	assert.NoError(t, err)
+
+	t.Run("semantic actions", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]int{
+			"1":                   1,
+			" 2 + 3 * 4 ":         14,
+			"(2 + 3) * 4":         20,
+			"100 / (7 - 2) - 1":   19,
+			"((1))\n+\t((2)) * 3": 7,
+		}
+
+		for input, expected := range cases {
+			node, err := g.Parse("Expr", input, calculatorActions())
+			assert.NoError(t, err, input)
+			assert.Equal(t, expected, node.Value, input)
+		}
+	})
+
+	t.Run("parse tree", func(t *testing.T) {
+		t.Parallel()
+
+		node, err := g.Parse("Sum", "1+2", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Sum", node.Name)
+		assert.Equal(t, "1+2", node.Text)
+		assert.Equal(t, 0, node.Start.Index())
+		assert.Equal(t, 3, node.End.Index())
+		assert.Nil(t, node.Value)
+
+		names := make([]string, len(node.Children))
+		for i, child := range node.Children {
+			names[i] = child.Name + ":" + child.Text
+		}
+
+		assert.Equal(t, []string{"Product:1", "AddOp:+", "Product:2"}, names)
+		assert.Equal(t, "Number", node.Children[2].Children[0].Children[0].Name)
+		assert.Equal(t, 2, node.Children[2].Start.Index())
+		assert.Nil(t, node.Child("MulOp"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := g.Parse("Expr", "1 + ", calculatorActions())
+		assert.EqualError(t, err, `Parse error at line=0 column=4 index=4: expected [ \t\n], [0-9] or "("`)
+
+		_, err = g.Parse("Expr", "(1 + 2", calculatorActions())
+		assert.EqualError(t, err, `Parse error at line=0 column=6 index=6: expected [0-9], [ \t\n], [*/], [+\-] or ")"`)
+
+		_, err = g.Parse("Expr", "1 2", calculatorActions())
+		assert.EqualError(t, err, `Parse error at line=0 column=2 index=2: expected [ \t\n], [*/], [+\-] or end of input`)
+
+		_, err = g.Parse("Sum", "1 2", nil)
+		assert.EqualError(t, err, `Parse error at line=0 column=2 index=2: expected [ \t\n], [*/], [+\-] or end of input`)
+
+		_, err = g.Parse("Value", "1)", nil)
+		assert.EqualError(t, err, `Parse error at line=0 column=1 index=1: expected [0-9], [ \t\n] or end of input`)
+
+		_, err = g.Parse("Expr", "4 / (2 - 2)", calculatorActions())
+		assert.EqualError(t, err, "Parse error at line=0 column=0 index=0: division by zero")
+
+		_, err = g.Parse("Unknown", "1", nil)
+		assert.EqualError(t, err, "undefined rule Unknown")
+
+		_, err = g.Compile("Expr", Actions{"Other": nil})
+		assert.EqualError(t, err, "action for undefined rule Other")
+	})
+
+	t.Run("combinator", func(t *testing.T) {
+		t.Parallel()
+
+		sum, err := g.Compile("Sum", calculatorActions())
+		assert.NoError(t, err)
+
+		comb := strings.SepBy(
+			0,
+			common.Cast(sum, func(node *Node) (int, error) {
+				return node.Value.(int), nil
+			}),
+			strings.Eq("expected ;", ';'),
+		)
+
+		result, err := strings.ParseString("1+2;3*4;5", comb)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3, 12, 5}, result)
+
+		_, err = strings.ParseString("+", sum)
+		assert.EqualError(t, err, `Parse error at line=0 column=0 index=0: expected [0-9] or "("`)
+	})
+
+	t.Run("ebnf", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := ParseEBNF(`
+			list   = "[", [ number, { ",", number } ], "]" ;
+			number = digit, { digit } ;
+			digit  = "0" | "1" | "2" | "3" | "4" | "5" | "6" | "7" | "8" | "9" ;
+		`)
+		assert.NoError(t, err)
+
+		node, err := g.Parse("list", "[1,23,456]", Actions{
+			"number": func(node *Node) (any, error) {
+				return strconv.Atoi(node.Text)
+			},
+			"list": func(node *Node) (any, error) {
+				return node.Values(), nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{1, 23, 456}, node.Value)
+
+		_, err = g.Parse("list", "[1,]", nil)
+		assert.EqualError(t, err, `Parse error at line=0 column=3 index=3: expected "0", "1", "2", "3", "4", "5", "6", "7", "8" or "9"`)
+	})
+}
@@ -0,0 +1,46 @@
+package grammar
+
+import (
+	"github.com/okneniz/parsec/strings"
+)
+
+// Node - node of parse tree, produced by each matched rule.
+// Children are nodes of rules called by the rule.
+type Node struct {
+	Name     string
+	Text     string
+	Start    strings.Position
+	End      strings.Position
+	Children []*Node
+	// Value - result of semantic action bound to the rule.
+	Value any
+}
+
+// Action - semantic action bound to rule, called after the rule
+// is matched (so children already have values), returned value is stored to node.
+// If it returns error, parsing is stopped.
+type Action func(node *Node) (any, error)
+
+// Actions - semantic actions by names of rules.
+type Actions map[string]Action
+
+// Child - first child with name, nil if not found.
+func (n *Node) Child(name string) *Node {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// Values - values of children.
+func (n *Node) Values() []any {
+	result := make([]any, len(n.Children))
+	for i, child := range n.Children {
+		result[i] = child.Value
+	}
+
+	return result
+}
@@ -0,0 +1,425 @@
+package grammar
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/lexer"
+	"github.com/okneniz/parsec/tokens"
+)
+
+type kind int
+
+const (
+	kindSpace kind = iota
+	kindComment
+	kindIdentifier
+	kindLiteral
+	kindClass
+	kindOperator
+)
+
+type (
+	token    = lexer.Token[kind]
+	position = lexer.Position
+)
+
+var (
+	pegLexer = lexer.MustNew(
+		lexer.Skip(lexer.Regexp(kindSpace, `\s+`)),
+		lexer.Skip(lexer.Regexp(kindComment, `#[^\n]*`)),
+		lexer.Regexp(kindIdentifier, `[A-Za-z_][A-Za-z0-9_]*`),
+		lexer.Regexp(kindLiteral, `'(?:[^'\\\n]|\\.)*'|"(?:[^"\\\n]|\\.)*"`),
+		lexer.Regexp(kindClass, `\[(?:[^\]\\\n]|\\.)*\]`),
+		lexer.Literal(kindOperator, "<-", "/", "&", "!", "?", "*", "+", "(", ")", "."),
+	)
+
+	ebnfLexer = lexer.MustNew(
+		lexer.Skip(lexer.Regexp(kindSpace, `\s+`)),
+		lexer.Skip(lexer.Regexp(kindComment, `\(\*(?:[^*]|\*+[^*)])*\*+\)`)),
+		lexer.Regexp(kindIdentifier, `[A-Za-z_][A-Za-z0-9_-]*`),
+		lexer.Regexp(kindLiteral, `'(?:[^'\\\n]|\\.)*'|"(?:[^"\\\n]|\\.)*"`),
+		lexer.Literal(
+			kindOperator,
+			"=", "::=", "|", ",", ";", ".",
+			"(", ")", "[", "]", "{", "}", "?", "*", "+",
+		),
+	)
+)
+
+// syntax - parser of grammar text, PEG or EBNF notation.
+type syntax struct {
+	ebnf bool
+}
+
+func (s syntax) rules(buffer common.Buffer[token, position]) ([]rule, common.Error[position]) {
+	result := make([]rule, 0)
+
+	for !buffer.IsEOF() {
+		r, err := s.definition(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func (s syntax) definition(buffer common.Buffer[token, position]) (rule, common.Error[position]) {
+	name, err := tokens.Eq[token, position]("expected rule name", kindIdentifier)(buffer)
+	if err != nil {
+		return rule{}, err
+	}
+
+	if s.ebnf {
+		_, err = operator("expected = after rule name", "=", "::=")(buffer)
+	} else {
+		_, err = operator("expected <- after rule name", "<-")(buffer)
+	}
+
+	if err != nil {
+		return rule{}, err
+	}
+
+	x, err := s.expression(buffer)
+	if err != nil {
+		return rule{}, err
+	}
+
+	if s.ebnf {
+		_, err = operator("expected ; or . at the end of rule", ";", ".")(buffer)
+		if err != nil {
+			return rule{}, err
+		}
+	}
+
+	return rule{name: name.Text(), expression: x, position: name.Start()}, nil
+}
+
+func (s syntax) expression(buffer common.Buffer[token, position]) (expression, common.Error[position]) {
+	separator := "/"
+	if s.ebnf {
+		separator = "|"
+	}
+
+	alternatives := make([]expression, 0, 1)
+
+	for {
+		x, err := s.sequence(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		alternatives = append(alternatives, x)
+
+		if !next(buffer, separator) {
+			break
+		}
+
+		_, _ = buffer.Read(true)
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+
+	return choice{alternatives: alternatives}, nil
+}
+
+func (s syntax) sequence(buffer common.Buffer[token, position]) (expression, common.Error[position]) {
+	items := make([]expression, 0, 1)
+
+	for s.startsItem(buffer) {
+		x, err := s.item(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, x)
+
+		if s.ebnf && next(buffer, ",") {
+			_, _ = buffer.Read(true)
+		}
+	}
+
+	switch len(items) {
+	case 0:
+		pos := buffer.Position()
+		return nil, common.NewParseError(pos, "expected expression")
+	case 1:
+		return items[0], nil
+	default:
+		return sequence{items: items}, nil
+	}
+}
+
+// startsItem - true if next token is start of sequence item,
+// identifier followed by <- is start of the next rule of PEG.
+func (s syntax) startsItem(buffer common.Buffer[token, position]) bool {
+	t, err := buffer.Read(false)
+	if err != nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case kindLiteral, kindClass:
+		return true
+	case kindIdentifier:
+		if s.ebnf {
+			return true
+		}
+
+		pos := buffer.Position()
+		_, _ = buffer.Read(true)
+		isRule := next(buffer, "<-")
+
+		return buffer.Seek(pos) == nil && !isRule
+	case kindOperator:
+		if s.ebnf {
+			return t.Text() == "(" || t.Text() == "[" || t.Text() == "{"
+		}
+
+		return t.Text() == "(" || t.Text() == "." || t.Text() == "&" || t.Text() == "!"
+	default:
+		return false
+	}
+}
+
+func (s syntax) item(buffer common.Buffer[token, position]) (expression, common.Error[position]) {
+	if !s.ebnf && (next(buffer, "&") || next(buffer, "!")) {
+		t, _ := buffer.Read(true)
+
+		x, err := s.suffix(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		return predicate{expression: x, negative: t.Text() == "!"}, nil
+	}
+
+	return s.suffix(buffer)
+}
+
+func (s syntax) suffix(buffer common.Buffer[token, position]) (expression, common.Error[position]) {
+	x, err := s.primary(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case next(buffer, "?"):
+			x = repetition{expression: x, min: 0, max: 1}
+		case next(buffer, "*"):
+			x = repetition{expression: x, min: 0, max: -1}
+		case next(buffer, "+"):
+			x = repetition{expression: x, min: 1, max: -1}
+		default:
+			return x, nil
+		}
+
+		_, _ = buffer.Read(true)
+	}
+}
+
+func (s syntax) primary(buffer common.Buffer[token, position]) (expression, common.Error[position]) {
+	pos := buffer.Position()
+
+	t, err := buffer.Read(true)
+	if err != nil {
+		return nil, common.NewParseError(pos, "expected expression")
+	}
+
+	switch t.Kind() {
+	case kindIdentifier:
+		return reference{name: t.Text(), position: t.Start()}, nil
+	case kindLiteral:
+		text, err := unquote(t.Text())
+		if err != nil {
+			return nil, common.NewParseError(pos, err.Error())
+		}
+
+		return literal{text: text}, nil
+	case kindClass:
+		x, err := parseClass(t.Text())
+		if err != nil {
+			return nil, common.NewParseError(pos, err.Error())
+		}
+
+		return x, nil
+	}
+
+	switch t.Text() {
+	case ".":
+		return anyRune{}, nil
+	case "(":
+		return s.group(buffer, ")", func(x expression) expression { return x })
+	case "[":
+		return s.group(buffer, "]", func(x expression) expression {
+			return repetition{expression: x, min: 0, max: 1}
+		})
+	case "{":
+		return s.group(buffer, "}", func(x expression) expression {
+			return repetition{expression: x, min: 0, max: -1}
+		})
+	}
+
+	return nil, common.NewParseError(pos, "expected expression")
+}
+
+func (s syntax) group(
+	buffer common.Buffer[token, position],
+	closing string,
+	wrap func(expression) expression,
+) (expression, common.Error[position]) {
+	x, err := s.expression(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = operator("expected "+closing, closing)(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrap(x), nil
+}
+
+// operator - read one of operators.
+func operator(errMessage string, ops ...string) common.Combinator[token, position, token] {
+	return tokens.Satisfy[token, position](errMessage, func(t token) bool {
+		if t.Kind() != kindOperator {
+			return false
+		}
+
+		for _, op := range ops {
+			if t.Text() == op {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// next - true if next token is operator op, doesn't change position.
+func next(buffer common.Buffer[token, position], op string) bool {
+	t, err := buffer.Read(false)
+	return err == nil && t.Kind() == kindOperator && t.Text() == op
+}
+
+// unquote - unquote literal in single or double quotes.
+func unquote(str string) (string, error) {
+	return unescape([]rune(str[1 : len(str)-1]))
+}
+
+func unescape(data []rune) (string, error) {
+	result := make([]rune, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' {
+			result = append(result, data[i])
+			continue
+		}
+
+		x, size, err := escaped(data[i+1:])
+		if err != nil {
+			return "", err
+		}
+
+		result = append(result, x)
+		i += size
+	}
+
+	return string(result), nil
+}
+
+// escaped - decode escape sequence after backslash,
+// returns rune and count of used runes.
+func escaped(data []rune) (rune, int, error) {
+	if len(data) == 0 {
+		return 0, 0, fmt.Errorf("invalid escape sequence")
+	}
+
+	switch data[0] {
+	case 'n':
+		return '\n', 1, nil
+	case 'r':
+		return '\r', 1, nil
+	case 't':
+		return '\t', 1, nil
+	case 'x', 'u':
+		size := 2
+		if data[0] == 'u' {
+			size = 4
+		}
+
+		if len(data) <= size {
+			return 0, 0, fmt.Errorf("invalid escape sequence \\%s", string(data))
+		}
+
+		x, err := strconv.ParseUint(string(data[1:size+1]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(x)) {
+			return 0, 0, fmt.Errorf("invalid escape sequence \\%s", string(data[:size+1]))
+		}
+
+		return rune(x), size + 1, nil
+	default:
+		return data[0], 1, nil
+	}
+}
+
+// parseClass - parse character class like [a-z_] or [^"\\].
+func parseClass(str string) (class, error) {
+	x := class{source: str}
+	data := []rune(str[1 : len(str)-1])
+
+	if len(data) > 0 && data[0] == '^' {
+		x.negated = true
+		data = data[1:]
+	}
+
+	for i := 0; i < len(data); {
+		from, next, err := classChar(data, i)
+		if err != nil {
+			return x, err
+		}
+
+		to := from
+
+		if next+1 < len(data) && data[next] == '-' {
+			to, next, err = classChar(data, next+1)
+			if err != nil {
+				return x, err
+			}
+
+			if from > to {
+				return x, fmt.Errorf("invalid range %c-%c in class %s", from, to, str)
+			}
+		}
+
+		x.ranges = append(x.ranges, runeRange{from: from, to: to})
+		i = next
+	}
+
+	return x, nil
+}
+
+// classChar - read char of class at index i,
+// returns it and index of the next char.
+func classChar(data []rune, i int) (rune, int, error) {
+	if data[i] != '\\' {
+		return data[i], i + 1, nil
+	}
+
+	r, size, err := escaped(data[i+1:])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return r, i + size + 1, nil
+}
@@ -0,0 +1,168 @@
+package lexer
+
+import (
+	"fmt"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+)
+
+// Lexer - split text to tokens by ordered rules.
+type Lexer[K comparable] struct {
+	rules    []Rule[K]
+	literals common.Tree[rune, strings.Position, int]
+}
+
+// New - make lexer from ordered rules.
+// On each position the rule with the longest match is used,
+// if several rules match the same text the first of them wins.
+// Returns error if one of rules is invalid.
+func New[K comparable](rules ...Rule[K]) (*Lexer[K], error) {
+	cases := make(map[string]common.Combinator[rune, strings.Position, int])
+
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		for _, literal := range rule.literals {
+			if _, exists := cases[literal]; !exists {
+				cases[literal] = common.Const[rune, strings.Position](i)
+			}
+		}
+	}
+
+	l := &Lexer[K]{
+		rules: rules,
+		literals: common.NewLongestPrefixTree(cases, func(s string) []rune {
+			return []rune(s)
+		}),
+	}
+
+	return l, nil
+}
+
+// MustNew - like New but panics if one of rules is invalid.
+func MustNew[K comparable](rules ...Rule[K]) *Lexer[K] {
+	l, err := New(rules...)
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// Lex - split text to tokens, tokens of skipped rules are omitted.
+// Returns ParseError if text can't be matched by any rule.
+func (l *Lexer[K]) Lex(data []rune) ([]Token[K], common.Error[strings.Position]) {
+	buffer := strings.Buffer(data)
+	result := make([]Token[K], 0)
+
+	for !buffer.IsEOF() {
+		start := buffer.Position()
+
+		i, err := l.next(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		if l.rules[i].skip {
+			continue
+		}
+
+		end := buffer.Position()
+
+		result = append(result, Token[K]{
+			kind:  l.rules[i].kind,
+			text:  string(data[start.Index():end.Index()]),
+			start: start,
+			end:   end,
+		})
+	}
+
+	return result, nil
+}
+
+// LexString - split string to tokens (see Lex).
+func (l *Lexer[K]) LexString(str string) ([]Token[K], common.Error[strings.Position]) {
+	return l.Lex([]rune(str))
+}
+
+// next - find the rule with the longest match, returns its index
+// and keep buffer position after matched text.
+func (l *Lexer[K]) next(buffer common.Buffer[rune, strings.Position]) (int, common.Error[strings.Position]) {
+	start := buffer.Position()
+
+	best := -1
+	end := start.Index()
+
+	if i, ok := l.lookup(buffer); ok {
+		best = i
+		end = buffer.Position().Index()
+	}
+
+	for i, rule := range l.rules {
+		if rule.match == nil {
+			continue
+		}
+
+		if err := buffer.Seek(start); err != nil {
+			return -1, common.NewParseError(start, err.Error())
+		}
+
+		if !rule.match(buffer) {
+			continue
+		}
+
+		pos := buffer.Position().Index()
+
+		if pos > end || (pos == end && best > i) {
+			best = i
+			end = pos
+		}
+	}
+
+	if best < 0 {
+		if err := buffer.Seek(start); err != nil {
+			return -1, common.NewParseError(start, err.Error())
+		}
+
+		x, _ := buffer.Read(false)
+		return -1, common.NewParseError(start, fmt.Sprintf("unexpected %q", x))
+	}
+
+	if buffer.Position().Index() == end {
+		return best, nil
+	}
+
+	// buffer can't seek to the end of text,
+	// so the best rule is applied again from the start.
+	if err := buffer.Seek(start); err != nil {
+		return -1, common.NewParseError(start, err.Error())
+	}
+
+	if l.rules[best].match != nil {
+		l.rules[best].match(buffer)
+	} else {
+		l.lookup(buffer)
+	}
+
+	return best, nil
+}
+
+// lookup - find the longest literal.
+func (l *Lexer[K]) lookup(buffer common.Buffer[rune, strings.Position]) (int, bool) {
+	start := buffer.Position()
+
+	value, err := l.literals.Lookup(buffer)
+	if err != nil || value == nil {
+		return -1, false
+	}
+
+	i, err := value(buffer)
+	if err != nil || buffer.Position().Index() == start.Index() {
+		return -1, false
+	}
+
+	return i, true
+}
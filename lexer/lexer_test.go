@@ -0,0 +1,164 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+	"github.com/okneniz/parsec/tokens"
+)
+
+type testKind int
+
+const (
+	testSpace testKind = iota
+	testComment
+	testKeyword
+	testIdent
+	testNumber
+	testOperator
+	testString
+)
+
+func (k testKind) String() string {
+	return [...]string{"space", "comment", "keyword", "ident", "number", "operator", "string"}[k]
+}
+
+func testLexer() *Lexer[testKind] {
+	return MustNew(
+		Skip(Regexp(testSpace, `\s+`)),
+		Skip(Regexp(testComment, `//[^\n]*`)),
+		Literal(testKeyword, "let", "if"),
+		Regexp(testIdent, `[a-zA-Z_]\w*`),
+		Match(testNumber, strings.Some(0, "expected number", strings.Try(strings.Digit("expected digit")))),
+		Literal(testOperator, "=", "==", "+", "/", ";"),
+		Regexp(testString, `"(?:[^"\\]|\\.)*"`),
+	)
+}
+
+type testLexeme struct {
+	kind   testKind
+	text   string
+	line   uint
+	column uint
+}
+
+func lexemes(data []Token[testKind]) []testLexeme {
+	result := make([]testLexeme, len(data))
+
+	for i, x := range data {
+		result[i] = testLexeme{
+			kind:   x.Kind(),
+			text:   x.Text(),
+			line:   x.Start().Line(),
+			column: x.Start().Column(),
+		}
+	}
+
+	return result
+}
+
+func TestLexer(t *testing.T) {
+	t.Parallel()
+
+	l := testLexer()
+
+	t.Run("longest match", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := l.LexString("let letter = 10 == x // comment\nif \"a\\\"b\" / 2;")
+		assert.NoError(t, err)
+		assert.Equal(t, []testLexeme{
+			{testKeyword, "let", 0, 0},
+			{testIdent, "letter", 0, 4},
+			{testOperator, "=", 0, 11},
+			{testNumber, "10", 0, 13},
+			{testOperator, "==", 0, 16},
+			{testIdent, "x", 0, 19},
+			{testKeyword, "if", 1, 0},
+			{testString, `"a\"b"`, 1, 3},
+			{testOperator, "/", 1, 10},
+			{testNumber, "2", 1, 12},
+			{testOperator, ";", 1, 13},
+		}, lexemes(result))
+
+		assert.Equal(t, 10, result[1].End().Index())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := l.LexString("  // nothing")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("unexpected rune", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := l.LexString("x = 1\ny = @")
+		assert.EqualError(t, err, "Parse error at line=1 column=4 index=10: unexpected '@'")
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(Literal(testKeyword, "let"), Regexp(testIdent, `[a-z`))
+		assert.ErrorContains(t, err, "rule 1: error parsing regexp")
+
+		_, err = New(Literal(testKeyword))
+		assert.EqualError(t, err, "rule 0: literal rule for keyword without literals")
+
+		_, err = New(Literal(testOperator, "+", ""))
+		assert.EqualError(t, err, "rule 0: empty literal for operator")
+
+		_, err = New(Rule[testKind]{})
+		assert.EqualError(t, err, "rule 0: rule without literals or matcher")
+
+		assert.Panics(t, func() {
+			MustNew(Regexp(testIdent, `(`))
+		})
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	l := testLexer()
+
+	type assignment struct {
+		name  string
+		value string
+	}
+
+	statement := common.SkipAfter(
+		tokens.Eq[Token[testKind], Position]("expected ;", testOperator),
+		common.Skip(
+			tokens.Eq[Token[testKind], Position]("expected let", testKeyword),
+			common.And(
+				tokens.Eq[Token[testKind], Position]("expected identifier", testIdent),
+				common.Skip(
+					tokens.Eq[Token[testKind], Position]("expected =", testOperator),
+					tokens.OneOf[Token[testKind], Position]("expected value", testNumber, testString),
+				),
+				func(name, value Token[testKind]) assignment {
+					return assignment{name: name.Text(), value: value.Text()}
+				},
+			),
+		),
+	)
+
+	program := common.Many(0, statement)
+
+	result, err := Parse(l, "let x = 1;\nlet y = \"z\";", program)
+	assert.NoError(t, err)
+	assert.Equal(t, []assignment{{"x", "1"}, {"y", `"z"`}}, result)
+
+	_, err = Parse(l, "let x = let;", statement)
+	assert.EqualError(t, err, "Parse error at token=3 span=line=0 column=8 index=8: expected value")
+
+	_, err = Parse(l, "let x = #;", statement)
+	assert.EqualError(t, err, "Parse error at line=0 column=8 index=8: unexpected '#'")
+}
@@ -0,0 +1,37 @@
+package lexer
+
+import (
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+	"github.com/okneniz/parsec/tokens"
+)
+
+// Position - position in tokens produced by lexer,
+// index of token and position of its first rune in text.
+type Position = tokens.Position[strings.Position]
+
+// Buffer - split text to tokens and make buffer to parse them by combinators.
+func (l *Lexer[K]) Buffer(data []rune) (common.Buffer[Token[K], Position], common.Error[strings.Position]) {
+	result, err := l.Lex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens.BufferWithSpans(result, Token[K].Start), nil
+}
+
+// Parse - split text to tokens by lexer and parse them by c combinator.
+// Returns ParseError of lexer or ParseError of combinator.
+func Parse[K comparable, S any](
+	l *Lexer[K],
+	str string,
+	parse common.Combinator[Token[K], Position, S],
+) (S, error) {
+	buf, err := l.Buffer([]rune(str))
+	if err != nil {
+		var null S
+		return null, err
+	}
+
+	return common.Parse(buf, parse)
+}
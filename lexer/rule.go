@@ -0,0 +1,90 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/strings"
+)
+
+type matcher func(common.Buffer[rune, strings.Position]) bool
+
+// Rule - rule to recognize tokens of one kind.
+type Rule[K comparable] struct {
+	kind     K
+	skip     bool
+	literals []string
+	match    matcher
+	err      error
+}
+
+// Literal - rule for tokens which text equal one of literals,
+// for example keywords or operators.
+func Literal[K comparable](kind K, literals ...string) Rule[K] {
+	r := Rule[K]{kind: kind, literals: literals}
+
+	if len(literals) == 0 {
+		r.err = fmt.Errorf("literal rule for %v without literals", kind)
+	}
+
+	for _, literal := range literals {
+		if literal == "" {
+			r.err = fmt.Errorf("empty literal for %v", kind)
+		}
+	}
+
+	return r
+}
+
+// Match - rule for tokens recognized by c combinator,
+// result of combinator is ignored, text of token is consumed runes.
+func Match[K comparable, S any](
+	kind K,
+	c common.Combinator[rune, strings.Position, S],
+) Rule[K] {
+	return Rule[K]{
+		kind: kind,
+		match: func(buffer common.Buffer[rune, strings.Position]) bool {
+			_, err := c(buffer)
+			return err == nil
+		},
+	}
+}
+
+// Regexp - rule for tokens matched by regular expression (RE2 syntax)
+// at the current position. The leftmost-longest match is used.
+func Regexp[K comparable](kind K, pattern string) Rule[K] {
+	re, err := regexp.Compile(`\A(?:` + pattern + `)`)
+	if err != nil {
+		return Rule[K]{kind: kind, err: err}
+	}
+
+	re.Longest()
+
+	return Match(kind, common.Regex[rune, strings.Position]("", re, func(x rune) rune {
+		return x
+	}))
+}
+
+// Skip - mark rule as skipped, tokens of this rule is not included
+// in the lexer output, for example white spaces or comments.
+func Skip[K comparable](rule Rule[K]) Rule[K] {
+	rule.skip = true
+	return rule
+}
+
+var errEmptyRule = errors.New("rule without literals or matcher")
+
+func (r Rule[K]) validate() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.literals) == 0 && r.match == nil {
+		return errEmptyRule
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package lexer
+
+import (
+	"fmt"
+
+	"github.com/okneniz/parsec/strings"
+)
+
+// Token - lexeme of kind K with its text and span in the source.
+type Token[K comparable] struct {
+	kind  K
+	text  string
+	start strings.Position
+	end   strings.Position
+}
+
+// Kind - kind of token.
+func (t Token[K]) Kind() K {
+	return t.kind
+}
+
+// Text - source text of token.
+func (t Token[K]) Text() string {
+	return t.text
+}
+
+// Start - position of the first rune of token.
+func (t Token[K]) Start() strings.Position {
+	return t.start
+}
+
+// End - position after the last rune of token.
+func (t Token[K]) End() strings.Position {
+	return t.end
+}
+
+// String - return string representation of token.
+func (t Token[K]) String() string {
+	return fmt.Sprintf("%v %q at %v", t.kind, t.text, t.start)
+}
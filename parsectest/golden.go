@@ -0,0 +1,98 @@
+package parsectest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// UpdateEnv - name of environment variable, golden files are rewritten
+// by actual values instead of comparison if it's not empty:
+//
+//	PARSECTEST_UPDATE=1 go test ./...
+const UpdateEnv = "PARSECTEST_UPDATE"
+
+// GoldenPath - path of golden file with name in testdata directory of package.
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// AssertGolden - assert that actual is equal to content of golden file with name
+// (see GoldenPath), file is written if UpdateEnv environment variable is set.
+func AssertGolden(t TestingT, name string, actual string) bool {
+	t.Helper()
+
+	path := GoldenPath(name)
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("can't create directory for golden file: %v", err)
+			return false
+		}
+
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Errorf("can't write golden file: %v", err)
+			return false
+		}
+
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("can't read golden file, run tests with %s=1 to create it: %v", UpdateEnv, err)
+		return false
+	}
+
+	return assert.Equal(t, string(expected), actual, "content of golden file %s", path)
+}
+
+// AssertErrorGolden - assert that error with its previous errors
+// is equal to golden file with name (see FormatError and AssertGolden).
+func AssertErrorGolden[P any](t TestingT, name string, err common.Error[P]) bool {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected parse error for golden file %s", GoldenPath(name))
+		return false
+	}
+
+	return AssertGolden(t, name, FormatError(err))
+}
+
+// AssertTreeGolden - assert that syntax tree is equal
+// to golden file with name (see common.Fprint and AssertGolden).
+func AssertTreeGolden[T any, P any](t TestingT, name string, node *common.SyntaxNode[T, P]) bool {
+	t.Helper()
+
+	if node == nil {
+		t.Errorf("expected syntax tree for golden file %s", GoldenPath(name))
+		return false
+	}
+
+	return AssertGolden(t, name, node.String())
+}
+
+// FormatError - format error and its previous errors, one error per line,
+// previous errors are indented.
+func FormatError[P any](err common.Error[P]) string {
+	b := new(strings.Builder)
+
+	var visit func(x common.Error[P], depth int)
+	visit = func(x common.Error[P], depth int) {
+		fmt.Fprintf(b, "%s%s\n", strings.Repeat("  ", depth), x.Error())
+
+		for _, prev := range x.Previous() {
+			visit(prev, depth+1)
+		}
+	}
+
+	visit(err, 0)
+
+	return b.String()
+}
@@ -0,0 +1,153 @@
+// Package parsectest - helpers to test parsers: assertions of results,
+// errors and remaining input, table-driven cases and golden files.
+package parsectest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// TestingT - subset of testing.TB used by assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertParses - assert that parser parses input without error,
+// result is equal to expected and remaining items of input are equal to remaining.
+// Input is read to the end to get remaining items.
+func AssertParses[T any, P any, S any](
+	t TestingT,
+	parser common.Combinator[T, P, S],
+	input common.Buffer[T, P],
+	expected S,
+	remaining []T,
+) bool {
+	t.Helper()
+
+	result, err := parser(input)
+	if err != nil {
+		t.Errorf("unexpected parse error: %v", err)
+		return false
+	}
+
+	ok := assert.Equal(t, expected, result, "result")
+
+	rest := Remaining(input)
+	if len(rest) != 0 || len(remaining) != 0 {
+		ok = assert.Equal(t, remaining, rest, "remaining input") && ok
+	}
+
+	return ok
+}
+
+// AssertFails - assert that parser fails on input at pos position
+// and each of expected messages is message of error or one of its previous errors.
+func AssertFails[T any, P any, S any](
+	t TestingT,
+	parser common.Combinator[T, P, S],
+	input common.Buffer[T, P],
+	pos P,
+	expected ...string,
+) bool {
+	t.Helper()
+
+	result, err := parser(input)
+	if err == nil {
+		t.Errorf("expected parse error, but parsed: %#v", result)
+		return false
+	}
+
+	ok := assert.Equal(t, pos, err.Position(), "position of error")
+
+	messages := Messages(err)
+	for _, x := range expected {
+		ok = assert.Contains(t, messages, x, "messages of error") && ok
+	}
+
+	return ok
+}
+
+// Remaining - read items of buffer until the end.
+func Remaining[T any, P any](buffer common.Buffer[T, P]) []T {
+	result := make([]T, 0)
+
+	for !buffer.IsEOF() {
+		x, err := buffer.Read(true)
+		if err != nil {
+			break
+		}
+
+		result = append(result, x)
+	}
+
+	return result
+}
+
+// Messages - messages of error and its previous errors in depth-first order.
+// Errors which are not ParseError are represented by Error method.
+func Messages[P any](err common.Error[P]) []string {
+	result := make([]string, 0)
+
+	var visit func(x common.Error[P])
+	visit = func(x common.Error[P]) {
+		if m, ok := x.(interface{ Message() string }); ok {
+			result = append(result, m.Message())
+		} else {
+			result = append(result, x.Error())
+		}
+
+		for _, prev := range x.Previous() {
+			visit(prev)
+		}
+	}
+
+	visit(err)
+
+	return result
+}
+
+// Case - case of table-driven test.
+// Input is parsed successfully to Output with Remaining items of input,
+// or fails at Position with Expected messages if Fails is true.
+type Case[T any, P any, S any] struct {
+	Name      string
+	Input     []T
+	Output    S
+	Remaining []T
+	Fails     bool
+	Position  P
+	Expected  []string
+}
+
+// Run - run each case as subtest of t with buffer made by buffer function.
+// Subtests are parallel.
+func Run[T any, P any, S any](
+	t *testing.T,
+	buffer func([]T) common.Buffer[T, P],
+	parser common.Combinator[T, P, S],
+	cases []Case[T, P, S],
+) {
+	t.Helper()
+
+	for i, c := range cases {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("case %d", i)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if c.Fails {
+				AssertFails(t, parser, buffer(c.Input), c.Position, c.Expected...)
+			} else {
+				AssertParses(t, parser, buffer(c.Input), c.Output, c.Remaining)
+			}
+		})
+	}
+}
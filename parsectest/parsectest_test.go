@@ -0,0 +1,213 @@
+package parsectest_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/bytes"
+	"github.com/okneniz/parsec/common"
+	"github.com/okneniz/parsec/parsectest"
+	"github.com/okneniz/parsec/strings"
+)
+
+// recorder - TestingT which records failures instead of failing test.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func runes(data []rune) common.Buffer[rune, strings.Position] {
+	return strings.Buffer(data)
+}
+
+func number() common.Combinator[rune, strings.Position, []rune] {
+	return strings.Some(1, "expected number", strings.Try(strings.Digit("expected digit")))
+}
+
+func TestAssertParses(t *testing.T) {
+	t.Parallel()
+
+	parsectest.AssertParses(t, number(), runes([]rune("123")), []rune("123"), nil)
+	parsectest.AssertParses(t, number(), runes([]rune("12ab")), []rune("12"), []rune("ab"))
+
+	parsectest.AssertParses(
+		t,
+		bytes.Some(1, "expected byte", bytes.Try(bytes.Eq("expected 0x01", 0x01))),
+		bytes.Buffer([]byte{0x01, 0x01, 0x02}),
+		[]byte{0x01, 0x01},
+		[]byte{0x02},
+	)
+
+	t.Run("failures", func(t *testing.T) {
+		t.Parallel()
+
+		r := new(recorder)
+		assert.False(t, parsectest.AssertParses(r, number(), runes([]rune("x")), []rune("1"), nil))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "unexpected parse error: Parse error at line=0 column=0 index=0: expected number")
+
+		r = new(recorder)
+		assert.False(t, parsectest.AssertParses(r, number(), runes([]rune("12a")), []rune("12"), nil))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "remaining input")
+
+		r = new(recorder)
+		assert.False(t, parsectest.AssertParses(r, number(), runes([]rune("12")), []rune("1"), nil))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "result")
+	})
+}
+
+func TestAssertFails(t *testing.T) {
+	t.Parallel()
+
+	comb := strings.Choice(
+		"expected number or letter",
+		strings.Try(number()),
+		strings.Try(strings.Some(1, "expected letters", strings.Try(strings.Letter("expected letter")))),
+	)
+
+	pos := strings.Buffer([]rune("")).Position()
+
+	parsectest.AssertFails(t, comb, runes([]rune("!")), pos, "expected number or letter")
+
+	t.Run("failures", func(t *testing.T) {
+		t.Parallel()
+
+		r := new(recorder)
+		assert.False(t, parsectest.AssertFails(r, comb, runes([]rune("1")), pos))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "expected parse error")
+
+		r = new(recorder)
+		assert.False(t, parsectest.AssertFails(r, comb, runes([]rune("!")), pos, "expected something else"))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "messages of error")
+	})
+}
+
+func TestMessages(t *testing.T) {
+	t.Parallel()
+
+	err := common.NewParseError(
+		1,
+		"expected value",
+		common.NewParseError(2, "expected number", common.NewParseError(3, "expected digit")),
+		common.NewParseError(2, "expected string"),
+	)
+
+	assert.Equal(t, []string{
+		"expected value",
+		"expected number",
+		"expected digit",
+		"expected string",
+	}, parsectest.Messages[int](err))
+
+	assert.Equal(t, `Parse error at 1: expected value
+  Parse error at 2: expected number
+    Parse error at 3: expected digit
+  Parse error at 2: expected string
+`, parsectest.FormatError[int](err))
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	parsectest.Run(t, runes, number(), []parsectest.Case[rune, strings.Position, []rune]{
+		{
+			Name:   "digits",
+			Input:  []rune("42"),
+			Output: []rune("42"),
+		},
+		{
+			Name:      "remaining",
+			Input:     []rune("4 2"),
+			Output:    []rune("4"),
+			Remaining: []rune(" 2"),
+		},
+		{
+			Name:     "letters",
+			Input:    []rune("abc"),
+			Fails:    true,
+			Position: strings.Buffer(nil).Position(),
+			Expected: []string{"expected number"},
+		},
+		{
+			Input:    []rune(""),
+			Fails:    true,
+			Position: strings.Buffer(nil).Position(),
+		},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := strings.ParseString("1+!", strings.Sequence(
+			0,
+			number(),
+			strings.Skip(strings.Eq("expected +", '+'), strings.Choice(
+				"expected number or letters",
+				strings.Try(number()),
+				strings.Try(strings.Some(1, "expected letters", strings.Try(strings.Letter("expected letter")))),
+			)),
+		))
+
+		if assert.Error(t, err) {
+			parsectest.AssertErrorGolden(t, "error", err)
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		num := strings.Node("number", number())
+		sum := strings.Node("sum", strings.Sequence(
+			0,
+			num,
+			strings.Skip(strings.Eq("expected +", '+'), num),
+		))
+
+		tree, err := strings.ParseString("12+3", sum)
+		if assert.NoError(t, err) {
+			parsectest.AssertTreeGolden(t, "tree", tree)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		if os.Getenv(parsectest.UpdateEnv) != "" {
+			t.Skip("golden files are updated")
+		}
+
+		r := new(recorder)
+		assert.False(t, parsectest.AssertGolden(r, "missing", "text"))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "run tests with PARSECTEST_UPDATE=1 to create it")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		if os.Getenv(parsectest.UpdateEnv) != "" {
+			t.Skip("golden files are updated")
+		}
+
+		r := new(recorder)
+		assert.False(t, parsectest.AssertGolden(r, "tree", "text"))
+		assert.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "content of golden file testdata/tree.golden")
+	})
+}
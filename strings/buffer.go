@@ -12,6 +12,10 @@ type buffer struct {
 	data         []rune
 	position     Position
 	newLineRunes map[rune]struct{}
+	// examined - index after the last examined rune
+	examined int
+	// memo - results of Memo combinators
+	memo map[memoKey]*memoEntry
 }
 
 var _ common.Buffer[rune, Position] = new(buffer)
@@ -64,9 +68,23 @@ func (b *buffer) Position() Position {
 
 // IsEOF - true if buffer ended.
 func (b *buffer) IsEOF() bool {
+	b.examine(b.position.index + 1)
 	return b.position.index >= len(b.data)
 }
 
+// Peek - return up to n next runes without reading them,
+// peeked runes are not counted as examined by Memo combinators.
+func (b *buffer) Peek(n int) []rune {
+	end := min(b.position.index+n, len(b.data))
+	return b.data[b.position.index:end]
+}
+
+func (b *buffer) examine(index int) {
+	if index > b.examined {
+		b.examined = index
+	}
+}
+
 // Buffer - make buffer which can read text on input and use
 // struct for positions.
 func Buffer(data []rune, newLineRunes ...rune) *buffer {
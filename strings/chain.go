@@ -65,6 +65,18 @@ func SepBy[T any, S any](
 	return common.SepBy[rune, Position, T](cap, body, sep)
 }
 
+// SepByFold - like SepBy, but combine data which returned by body combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value (see common.SepByFold).
+func SepByFold[T any, S any, A any](
+	init A,
+	body common.Combinator[rune, Position, T],
+	sep common.Combinator[rune, Position, S],
+	f func(A, T) A,
+) common.Combinator[rune, Position, A] {
+	return common.SepByFold(init, body, sep, f)
+}
+
 // SepBy1 - read one or more occurrences of data readed by c combinator,
 // separated by sep combinator.
 // Returns a slice of values returned by p.
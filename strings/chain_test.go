@@ -367,6 +367,43 @@ func TestSepBy(t *testing.T) {
 	})
 }
 
+func TestSepByFold(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[string]{
+		{
+			comb: SepByFold(
+				"",
+				NotEq("expected not ','", ','),
+				Eq("expected ','", ','),
+				func(acc string, x rune) string { return acc + string(x) },
+			),
+			cases: []testCase[string]{
+				{
+					input:  "",
+					output: "",
+				},
+				{
+					input:  "a,b,c",
+					output: "abc",
+				},
+				{
+					input:  ",",
+					output: "",
+				},
+				{
+					input:  "a,b,c,",
+					output: "abc",
+				},
+				{
+					input:  "abc",
+					output: "a",
+				},
+			},
+		},
+	})
+}
+
 func TestSepBy1(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +181,77 @@ func TestTry(t *testing.T) {
 	})
 }
 
+func TestLookAhead(t *testing.T) {
+	t.Parallel()
+
+	runTestsString(t, []test[[]rune]{
+		{
+			comb: Sequence(
+				0,
+				LookAhead(Eq("expected 'a'", 'a')),
+				Any(),
+			),
+			cases: []testCase[[]rune]{
+				{
+					input:  "ab",
+					output: []rune{'a', 'a'},
+				},
+				{
+					input:  "ba",
+					output: nil,
+					err: common.NewParseError(
+						Position{
+							line:   0,
+							column: 0,
+							index:  0,
+						},
+						"expected 'a'",
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestNotFollowedBy(t *testing.T) {
+	t.Parallel()
+
+	runTestsString(t, []test[[]rune]{
+		{
+			comb: Sequence(
+				0,
+				Eq("expected 'a'", 'a'),
+				SkipAfter(
+					NotFollowedBy("unexpected 'c'", Eq("expected 'c'", 'c')),
+					Any(),
+				),
+			),
+			cases: []testCase[[]rune]{
+				{
+					input:  "ab",
+					output: []rune{'a', 'b'},
+				},
+				{
+					input:  "abc",
+					output: nil,
+					err: common.NewParseError(
+						Position{
+							line:   0,
+							column: 2,
+							index:  2,
+						},
+						"unexpected 'c'",
+					),
+				},
+				{
+					input:  "abd",
+					output: []rune{'a', 'b'},
+				},
+			},
+		},
+	})
+}
+
 func TestBetween(t *testing.T) {
 	t.Parallel()
 
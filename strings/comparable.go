@@ -1,6 +1,8 @@
 package strings
 
 import (
+	"strconv"
+
 	"github.com/okneniz/parsec/common"
 )
 
@@ -69,7 +71,13 @@ func Map[K comparable, V any](
 // String - read input text and match with string passed by first argument.
 // If the text not matched then it returns ParseError error.
 func String(errMessage, str string) common.Combinator[rune, Position, string] {
-	return func(buffer common.Buffer[rune, Position]) (string, common.Error[Position]) {
+	description := &common.Description{
+		Kind:  common.KindTerminal,
+		Name:  strconv.Quote(str),
+		Items: []rune(str),
+	}
+
+	return common.Describe(func(buffer common.Buffer[rune, Position]) (string, common.Error[Position]) {
 		pos := buffer.Position()
 
 		for _, r := range str {
@@ -84,7 +92,7 @@ func String(errMessage, str string) common.Combinator[rune, Position, string] {
 		}
 
 		return str, nil
-	}
+	}, description)
 }
 
 // MapStrings - Reads text from the input buffer using the combinator and
@@ -0,0 +1,141 @@
+package strings
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// tests of coverage are not parallel, because coverage is enabled globally
+func TestCoverage(t *testing.T) {
+	coverage := common.NewCoverage()
+	common.EnableCoverage(coverage)
+	defer common.DisableCoverage()
+
+	sign := Optional(Eq("expected sign", '-'), 0)
+	number := Named("number", Skip(sign, Some(0, "expected number", Try(Digit("expected digit")))))
+	constant := MapStrings("expected constant", map[string][]rune{
+		"pi":   []rune("3.14"),
+		"zero": []rune("0"),
+	})
+	value := Choice("expected value", Try(number), constant)
+
+	for _, input := range []string{"12", "pi"} {
+		_, err := ParseString(input, value)
+		assert.NoError(t, err)
+	}
+
+	points := make([]common.CoveragePoint, 0)
+	for _, point := range coverage.Points() {
+		if strings.HasPrefix(point.Location, "strings/coverage_test.go:") {
+			point.Location = strings.TrimPrefix(point.Location, "strings/")
+			points = append(points, point)
+		}
+	}
+
+	assert.Equal(t, []common.CoveragePoint{
+		{
+			Kind:     "optional",
+			Location: "coverage_test.go:19",
+			Branches: []common.CoverageBranch{
+				{Label: "present"},
+				{Label: "absent", Count: 2},
+			},
+		},
+		{
+			Kind:     "named",
+			Location: "coverage_test.go:20",
+			Name:     "number",
+			Branches: []common.CoverageBranch{
+				{Label: "success", Count: 1},
+			},
+		},
+		{
+			Kind:     "map",
+			Location: "coverage_test.go:21",
+			Name:     "expected constant",
+			Branches: []common.CoverageBranch{
+				{Label: "pi", Count: 1},
+				{Label: "zero"},
+			},
+		},
+		{
+			Kind:     "choice",
+			Location: "coverage_test.go:25",
+			Name:     "expected value",
+			Branches: []common.CoverageBranch{
+				{Label: "alternative 1", Count: 1},
+				{Label: "alternative 2", Count: 1},
+			},
+		},
+	}, points)
+
+	t.Run("text", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, coverage.WriteText(out))
+
+		assert.Contains(t, out.String(), `strings/coverage_test.go:19: optional "" 1/2
+	not covered: present
+strings/coverage_test.go:20: named "number" 1/1
+strings/coverage_test.go:21: map "expected constant" 1/2
+	not covered: zero
+strings/coverage_test.go:25: choice "expected value" 2/2
+`)
+		assert.Regexp(t, `coverage: \d+\.\d% of branches\n$`, out.String())
+	})
+
+	t.Run("html", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, coverage.WriteHTML(out))
+
+		assert.Contains(
+			t,
+			out.String(),
+			`<tr class="uncovered"><td>strings/coverage_test.go:21</td><td>map</td><td>expected constant</td><td>zero</td><td>0</td></tr>`,
+		)
+		assert.Contains(
+			t,
+			out.String(),
+			`<tr class="covered"><td>strings/coverage_test.go:25</td><td>choice</td><td>expected value</td><td>alternative 2</td><td>1</td></tr>`,
+		)
+	})
+
+	t.Run("made while disabled", func(t *testing.T) {
+		common.DisableCoverage()
+		defer common.EnableCoverage(coverage)
+
+		uncovered := Choice("expected uncovered", Try(Digit("expected digit")), Letter("expected letter"))
+
+		common.EnableCoverage(coverage)
+
+		_, err := ParseString("a", uncovered)
+		assert.NoError(t, err)
+
+		for _, point := range coverage.Points() {
+			assert.NotEqual(t, "expected uncovered", point.Name)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		common.DisableCoverage()
+		defer common.EnableCoverage(coverage)
+
+		_, err := ParseString("-1", value)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, countPresent(coverage))
+	})
+}
+
+func countPresent(coverage *common.Coverage) int {
+	for _, point := range coverage.Points() {
+		if point.Location == "strings/coverage_test.go:19" {
+			return point.Branches[0].Count
+		}
+	}
+
+	return -1
+}
@@ -13,3 +13,32 @@ func Trace[T any](
 ) common.Combinator[rune, Position, T] {
 	return common.Trace[rune, Position, T](l, m, c)
 }
+
+// Named - give a name to c combinator for tracing (see common.EnableTracing).
+// If tracing is disabled it just calls c combinator.
+// Described as rule with name (see common.DescriptionOf).
+func Named[T any](
+	name string,
+	c common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, T] {
+	return common.Named(name, c)
+}
+
+// Ref - reference to combinator declared later by pointer, useful for recursive grammars.
+// Described as reference to rule with name (see common.DescriptionOf).
+func Ref[T any](
+	name string,
+	c *common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, T] {
+	return common.Ref(name, c)
+}
+
+// Debug - start parsing of text by c combinator under control of d debugger
+// (see common.Debug).
+func Debug[T any](
+	d *common.Debugger,
+	str string,
+	c common.Combinator[rune, Position, T],
+) {
+	common.Debug(d, c, Buffer([]rune(str)))
+}
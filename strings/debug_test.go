@@ -0,0 +1,265 @@
+package strings
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// previewlessSink - sink which doesn't need preview of input.
+type previewlessSink struct {
+	previews []string
+}
+
+func (s *previewlessSink) Emit(e common.Event) {
+	if e.Kind == common.EventEnter {
+		s.previews = append(s.previews, e.Preview)
+	}
+}
+
+func (s *previewlessSink) NeedsPreview() bool {
+	return false
+}
+
+// tests of tracing are not parallel, because tracing is enabled globally
+func TestNamed(t *testing.T) {
+	digit := Named("digit", Digit("expected digit"))
+	number := Named("number", Some(0, "expected number", Try(digit)))
+	sum := Named("sum", Sequence(0, number, Skip(Eq("expected +", '+'), number)))
+
+	t.Run("events", func(t *testing.T) {
+		events := make([]common.Event, 0)
+		common.EnableTracing(common.SinkFunc(func(e common.Event) {
+			e.Duration = 0
+			events = append(events, e)
+		}), "sum", "number")
+		defer common.DisableTracing()
+
+		_, err := ParseString("1+x", sum)
+		assert.Error(t, err)
+
+		assert.Equal(t, []common.Event{
+			{
+				Kind:    common.EventEnter,
+				Name:    "sum",
+				Start:   Position{},
+				Preview: "1+x",
+			},
+			{
+				Kind:    common.EventEnter,
+				Name:    "number",
+				Depth:   1,
+				Start:   Position{},
+				Preview: "1+x",
+			},
+			{
+				Kind:   common.EventExit,
+				Name:   "number",
+				Depth:  1,
+				Start:  Position{},
+				End:    Position{column: 1, index: 1},
+				Result: []rune("1"),
+			},
+			{
+				Kind:    common.EventEnter,
+				Name:    "number",
+				Depth:   1,
+				Start:   Position{column: 2, index: 2},
+				Preview: "x",
+			},
+			{
+				Kind:  common.EventBacktrack,
+				Name:  "number",
+				Depth: 1,
+				Start: Position{column: 2, index: 2},
+				End:   Position{column: 2, index: 2},
+				Err:   common.NewParseError(Position{column: 2, index: 2}, "expected number"),
+			},
+			{
+				Kind:  common.EventBacktrack,
+				Name:  "sum",
+				Start: Position{},
+				End:   Position{column: 2, index: 2},
+				Err:   common.NewParseError(Position{column: 2, index: 2}, "expected number"),
+			},
+		}, events)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		common.EnableTracing(common.TextSink(out))
+		defer common.DisableTracing()
+
+		_, err := ParseString("12+3", sum)
+		assert.NoError(t, err)
+
+		durations := regexp.MustCompile(`\([^)]+\)\n`)
+		assert.Equal(t, `> sum at line=0 column=0 index=0 "12+3"
+  > number at line=0 column=0 index=0 "12+3"
+    > digit at line=0 column=0 index=0 "12+3"
+    < digit at line=0 column=1 index=1 (?)
+    > digit at line=0 column=1 index=1 "2+3"
+    < digit at line=0 column=2 index=2 (?)
+    > digit at line=0 column=2 index=2 "+3"
+    ! digit at line=0 column=2 index=2: Parse error at line=0 column=2 index=2: expected digit (?)
+  < number at line=0 column=2 index=2 (?)
+  > number at line=0 column=3 index=3 "3"
+    > digit at line=0 column=3 index=3 "3"
+    < digit at line=0 column=4 index=4 (?)
+  < number at line=0 column=4 index=4 (?)
+< sum at line=0 column=4 index=4 (?)
+`, durations.ReplaceAllString(out.String(), "(?)\n"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		common.EnableTracing(common.JSONSink(out), "number")
+		defer common.DisableTracing()
+
+		_, err := ParseString("1+2", sum)
+		assert.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		assert.Len(t, lines, 4)
+
+		event := make(map[string]any)
+		assert.NoError(t, json.Unmarshal(lines[1], &event))
+		assert.Contains(t, event, "duration_ns")
+		delete(event, "duration_ns")
+		assert.Equal(t, map[string]any{
+			"event": "exit",
+			"name":  "number",
+			"depth": float64(0),
+			"start": "line=0 column=0 index=0",
+			"end":   "line=0 column=1 index=1",
+		}, event)
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		logger := slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{
+			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey || a.Key == "duration" {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+
+		common.EnableTracing(common.SlogSink(logger, slog.LevelInfo), "sum")
+		defer common.DisableTracing()
+
+		_, err := ParseString("1+2", sum)
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`level=INFO msg="parsec enter" name=sum depth=0 start="line=0 column=0 index=0" preview=1+2
+level=INFO msg="parsec exit" name=sum depth=0 start="line=0 column=0 index=0" end="line=0 column=3 index=3"
+`,
+			out.String(),
+		)
+
+		out.Reset()
+		common.EnableTracing(common.SlogSink(logger, slog.LevelDebug), "sum")
+
+		_, err = ParseString("1+2", sum)
+		assert.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("without preview", func(t *testing.T) {
+		sink := new(previewlessSink)
+		common.EnableTracing(sink, "sum", "number")
+		defer common.DisableTracing()
+
+		_, err := ParseString("1+2", sum)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"", "", ""}, sink.previews)
+	})
+
+	t.Run("depth of concurrent parsers", func(t *testing.T) {
+		var mu sync.Mutex
+		depths := make(map[string][]int)
+
+		common.EnableTracing(common.SinkFunc(func(e common.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			depths[e.Name] = append(depths[e.Name], e.Depth)
+		}), "sum", "number")
+		defer common.DisableTracing()
+
+		var wg sync.WaitGroup
+
+		for range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for range 100 {
+					_, err := ParseString("12+34", sum)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Len(t, depths["sum"], 800)
+		assert.Len(t, depths["number"], 1600)
+
+		for _, depth := range depths["sum"] {
+			assert.Equal(t, 0, depth)
+		}
+
+		for _, depth := range depths["number"] {
+			assert.Equal(t, 1, depth)
+		}
+	})
+
+	t.Run("preview of memoized combinators", func(t *testing.T) {
+		common.EnableTracing(common.SinkFunc(func(common.Event) {}))
+		defer common.DisableTracing()
+
+		calls := 0
+		word := Cast(
+			Some(0, "expected word", Try(Letter("expected letter"))),
+			func(x []rune) (string, error) {
+				calls++
+				return string(x), nil
+			},
+		)
+
+		line := Memo(Named("line", SkipAfter(Eq("expected new line", '\n'), word)))
+		parser := Many(0, Try(line))
+
+		buf := Buffer([]rune("foo\nbar\nbaz\nqux\n"))
+
+		_, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, calls)
+
+		// preview of the first lines must not invalidate them
+		assert.NoError(t, buf.Edit(12, 15, "quux"))
+
+		calls = 0
+		result, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "bar", "baz", "quux"}, result)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		result, err := ParseString("1+2", sum)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]rune{{'1'}, {'2'}}, result)
+	})
+}
@@ -0,0 +1,193 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestDebugger(t *testing.T) {
+	t.Parallel()
+
+	digit := Named("digit", Digit("expected digit"))
+	number := Named("number", Some(0, "expected number", Try(digit)))
+	sum := Named("sum", Sequence(0, number, Skip(Eq("expected +", '+'), number)))
+
+	type step struct {
+		kind  common.EventKind
+		name  string
+		index int
+	}
+
+	stepOf := func(event common.Event) step {
+		pos := event.Start
+		if event.Kind != common.EventEnter {
+			pos = event.End
+		}
+
+		return step{kind: event.Kind, name: event.Name, index: pos.(Position).Index()}
+	}
+
+	t.Run("step", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		Debug(d, "1+2", sum)
+
+		steps := make([]step, 0)
+		for {
+			event, ok := d.Step()
+			if !ok {
+				break
+			}
+
+			steps = append(steps, stepOf(event))
+		}
+
+		assert.Equal(t, []step{
+			{common.EventEnter, "sum", 0},
+			{common.EventEnter, "number", 0},
+			{common.EventEnter, "digit", 0},
+			{common.EventExit, "digit", 1},
+			{common.EventEnter, "digit", 1},
+			{common.EventBacktrack, "digit", 2},
+			{common.EventExit, "number", 1},
+			{common.EventEnter, "number", 2},
+			{common.EventEnter, "digit", 2},
+			{common.EventExit, "digit", 3},
+			{common.EventExit, "number", 3},
+			{common.EventExit, "sum", 3},
+		}, steps)
+
+		assert.True(t, d.Done())
+
+		result, err := d.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]rune{[]rune("1"), []rune("2")}, result)
+	})
+
+	t.Run("next and out", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		Debug(d, "1+2", sum)
+
+		event, ok := d.Step()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventEnter, "sum", 0}, stepOf(event))
+
+		event, ok = d.Step()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventEnter, "number", 0}, stepOf(event))
+
+		event, ok = d.Next()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventExit, "number", 1}, stepOf(event))
+		assert.Equal(t, []rune("1"), event.Result)
+
+		stack := d.Stack()
+		assert.Len(t, stack, 1)
+		assert.Equal(t, "sum", stack[0].Name)
+
+		event, ok = d.Out()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventExit, "sum", 3}, stepOf(event))
+
+		_, ok = d.Next()
+		assert.False(t, ok)
+		assert.True(t, d.Done())
+	})
+
+	t.Run("breakpoints", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		d.BreakOnRule("number")
+		d.BreakAt(2)
+		d.BreakAt(10)
+
+		assert.Equal(t, []string{"index 10", "index 2", "rule number"}, d.Breakpoints())
+
+		Debug(d, "1+2", sum)
+
+		event, ok := d.Continue()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventEnter, "number", 0}, stepOf(event))
+
+		event, ok = d.Continue()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventEnter, "number", 2}, stepOf(event))
+
+		stack := d.Stack()
+		assert.Len(t, stack, 2)
+		assert.Equal(t, "sum", stack[0].Name)
+		assert.Equal(t, "number", stack[1].Name)
+
+		d.ClearBreakpoints()
+		assert.Empty(t, d.Breakpoints())
+
+		_, ok = d.Continue()
+		assert.False(t, ok)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		d.BreakOnRule("sum")
+		Debug(d, "1+x", sum)
+
+		event, ok := d.Continue()
+		assert.True(t, ok)
+		assert.Equal(t, "sum", event.Name)
+
+		event, ok = d.Out()
+		assert.True(t, ok)
+		assert.Equal(t, step{common.EventBacktrack, "sum", 2}, stepOf(event))
+		assert.EqualError(t, event.Err, "Parse error at line=0 column=2 index=2: expected number")
+
+		_, ok = d.Step()
+		assert.False(t, ok)
+
+		_, err := d.Result()
+		assert.EqualError(t, err, "Parse error at line=0 column=2 index=2: expected number")
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		Debug(d, "1+2", sum)
+
+		_, ok := d.Step()
+		assert.True(t, ok)
+		assert.False(t, d.Done())
+
+		d.Stop()
+		assert.True(t, d.Done())
+
+		_, err := d.Result()
+		assert.ErrorIs(t, err, common.ErrDebugStopped)
+
+		_, ok = d.Step()
+		assert.False(t, ok)
+	})
+
+	t.Run("other parsers", func(t *testing.T) {
+		t.Parallel()
+
+		d := common.NewDebugger()
+		Debug(d, "1+2", sum)
+		defer d.Stop()
+
+		_, ok := d.Step()
+		assert.True(t, ok)
+
+		// parsing is paused, but other parsers are not blocked by debugger
+		result, err := ParseString("3+4", sum)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]rune{{'3'}, {'4'}}, result)
+	})
+}
@@ -0,0 +1,184 @@
+package strings
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// tests of descriptions are not parallel, because descriptions are enabled globally
+func TestDescription(t *testing.T) {
+	common.EnableDescriptions()
+	defer common.DisableDescriptions()
+
+	var expr common.Combinator[rune, Position, string]
+
+	number := Named("number", Cast(Some(1, "expected digit", Try(Digit("digit"))), func(x []rune) (string, error) {
+		return string(x), nil
+	}))
+
+	list := Named("list", Between(
+		String("expected (", "("),
+		Cast(SepBy(1, Ref("expr", &expr), Eq("expected ,", ',')), func(x []string) (string, error) {
+			return "", nil
+		}),
+		String("expected )", ")"),
+	))
+
+	expr = Named("expr", Choice(
+		"expected expression",
+		Try(number),
+		list,
+		Skip(Optional(Eq("expected -", '-'), 0), String("expected nil", "nil")),
+	))
+
+	d := common.DescriptionOf(expr)
+
+	t.Run("parsing", func(t *testing.T) {
+		result, err := ParseString("(1,(2))", expr)
+		assert.NoError(t, err)
+		assert.Equal(t, "", result)
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		names := make([]string, 0)
+		for _, rule := range d.Rules() {
+			names = append(names, rule.Name)
+		}
+
+		assert.Equal(t, []string{"expr", "number", "list"}, names)
+	})
+
+	t.Run("ebnf", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		assert.NoError(t, d.WriteEBNF(buf))
+
+		assert.Equal(
+			t,
+			`expr = number | list | [ "-" ] , "nil" ;
+number = ? digit ? , { ? digit ? } ;
+list = "(" , [ expr , { "," , expr } ] , ")" ;
+`,
+			buf.String(),
+		)
+	})
+
+	t.Run("not described", func(t *testing.T) {
+		c := Sequence(0, Eq("expected a", 'a'), func(buffer common.Buffer[rune, Position]) (rune, common.Error[Position]) {
+			return 'b', nil
+		})
+
+		assert.Equal(t, `"a" , ? unknown ?`, common.DescriptionOf(c).String())
+
+		buf := new(bytes.Buffer)
+		assert.NoError(t, common.DescriptionOf(c).WriteEBNF(buf))
+		assert.Equal(t, "grammar = \"a\" , ? unknown ? ;\n", buf.String())
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		c := Sequence(
+			0,
+			LookAhead(Eq("expected a", 'a')),
+			Skip(NotFollowedBy("unexpected b", Eq("expected b", 'b')), Any()),
+		)
+
+		d := common.DescriptionOf(c)
+		assert.Equal(t, `? followed by "a" ? , ( ? not followed by "b" ? , ? any ? )`, d.String())
+
+		buf := new(bytes.Buffer)
+		assert.NoError(t, d.WriteDOT(buf))
+		assert.Contains(t, buf.String(), `[label="lookAhead", shape=box, style=rounded];`)
+		assert.Contains(t, buf.String(), `[label="notFollowedBy", shape=box, style=rounded];`)
+
+		buf.Reset()
+		assert.NoError(t, d.WriteSVG(buf))
+		assert.Contains(t, buf.String(), `>followed by &#34;a&#34;</text>`)
+		assert.Contains(t, buf.String(), `>not followed by &#34;b&#34;</text>`)
+	})
+
+	t.Run("described by hand", func(t *testing.T) {
+		hand := func(common.Buffer[rune, Position]) (rune, common.Error[Position]) {
+			return 'x', nil
+		}
+
+		a := common.Describe(hand, &common.Description{Kind: common.KindClass, Name: "a"})
+		b := common.Describe(hand, &common.Description{Kind: common.KindClass, Name: "b"})
+
+		assert.Equal(t, "? a ?", common.DescriptionOf(a).String())
+		assert.Equal(t, "? b ?", common.DescriptionOf(b).String())
+
+		result, err := ParseString("", a)
+		assert.NoError(t, err)
+		assert.Equal(t, 'x', result)
+
+		calls := 0
+		reader := func(buffer common.Buffer[rune, Position]) (rune, common.Error[Position]) {
+			calls++
+
+			x, err := buffer.Read(true)
+			if err != nil {
+				return 0, common.NewParseError(buffer.Position(), err.Error())
+			}
+
+			return x, nil
+		}
+
+		// combinators without description are not called by introspection
+		assert.Equal(t, common.KindUnknown, common.DescriptionOf(reader).Kind)
+		assert.Equal(t, `? unknown ? , ? any ?`, common.DescriptionOf(Sequence(0, reader, Any())).String())
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		assert.NoError(t, d.WriteDOT(buf))
+
+		dot := buf.String()
+		assert.Contains(t, dot, "digraph grammar {\n")
+		assert.Contains(t, dot, `[label="expr", shape=box, style=bold];`)
+		assert.Contains(t, dot, `[label="expr", shape=box, style=dashed];`)
+		assert.Contains(t, dot, `[label="\"nil\"", shape=ellipse];`)
+		assert.Contains(t, dot, "[style=dashed];\n")
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		assert.NoError(t, d.WriteSVG(buf))
+
+		decoder := xml.NewDecoder(buf)
+		texts := make([]string, 0)
+
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if x, ok := token.(xml.CharData); ok && len(bytes.TrimSpace(x)) > 0 {
+				texts = append(texts, string(x))
+			}
+		}
+
+		assert.Contains(t, texts, "expr")
+		assert.Contains(t, texts, "list")
+		assert.Contains(t, texts, `"("`)
+		assert.Contains(t, texts, "digit")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		common.DisableDescriptions()
+		defer common.EnableDescriptions()
+
+		c := Eq("expected a", 'a')
+		assert.Equal(t, common.KindUnknown, common.DescriptionOf(c).Kind)
+	})
+}
@@ -0,0 +1,27 @@
+package strings
+
+import (
+	"math/rand/v2"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Generator - make generator of random texts parsed by c combinator (see common.Generator).
+// Descriptions must be enabled before making of c combinator (see common.EnableDescriptions).
+// Runes of classes are taken from printable ASCII characters, tab, new line and alphabet.
+func Generator[T any](
+	rnd *rand.Rand,
+	c common.Combinator[rune, Position, T],
+	alphabet ...rune,
+) (*common.Generator[rune], error) {
+	runes := make([]rune, 0, 98+len(alphabet))
+	runes = append(runes, '\t', '\n')
+
+	for x := rune(' '); x <= '~'; x++ {
+		runes = append(runes, x)
+	}
+
+	runes = append(runes, alphabet...)
+
+	return common.NewGenerator(rnd, common.DescriptionOf(c), runes)
+}
@@ -0,0 +1,183 @@
+package strings
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	ohsnap "github.com/okneniz/oh-snap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// listGrammar - grammar of nested lists of numbers and strings, like [1,"a",[]].
+// Descriptions must be enabled before the call.
+func listGrammar() common.Combinator[rune, Position, int] {
+	var value common.Combinator[rune, Position, int]
+
+	one := func([]rune) (int, error) { return 1, nil }
+
+	number := Named("number", Cast(Some(1, "expected digit", Try(Range("expected digit", '0', '9'))), one))
+
+	str := Named("string", Cast(Between(
+		Eq("expected quote", '"'),
+		Many(0, Try(NoneOf("expected not quote", '"', '\\', '\n'))),
+		Eq("expected quote", '"'),
+	), one))
+
+	list := Named("list", Cast(Between(
+		Eq("expected [", '['),
+		SepBy(0, Ref("value", &value), Eq("expected ,", ',')),
+		Eq("expected ]", ']'),
+	), func(xs []int) (int, error) {
+		total := 1
+		for _, x := range xs {
+			total += x
+		}
+
+		return total, nil
+	}))
+
+	value = Named("value", Choice("expected value", Try(number), Try(str), Try(list)))
+
+	return SkipAfter(EOF(), value)
+}
+
+// tests of generator are not parallel, because descriptions are enabled globally
+func TestGenerator(t *testing.T) {
+	common.EnableDescriptions()
+	defer common.DisableDescriptions()
+
+	grammar := listGrammar()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %v", seed)
+	rnd := rand.New(rand.NewPCG(0, uint64(seed)))
+
+	g, err := Generator(rnd, grammar)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var _ ohsnap.Arbitrary[[]rune] = g
+
+	t.Run("valid inputs", func(t *testing.T) {
+		ohsnap.Check(t, 1000, g, func(input []rune) bool {
+			_, err := Parse(input, grammar)
+			if err != nil {
+				t.Logf("input: %q", string(input))
+				t.Logf("error: %v", err)
+				return false
+			}
+
+			return true
+		})
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		predicates := SkipAfter(EOF(), Sequence(
+			0,
+			LookAhead(Range("expected letter", 'a', 'z')),
+			Skip(NotFollowedBy("unexpected x", Eq("expected x", 'x')), Range("expected letter", 'a', 'w')),
+		))
+
+		g, err := Generator(rnd, predicates)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for range 100 {
+			input := g.Generate()
+			assert.Len(t, input, 1)
+
+			_, err := Parse(input, predicates)
+			assert.NoError(t, err, "input: %q", string(input))
+		}
+	})
+
+	t.Run("bounded recursion", func(t *testing.T) {
+		bounded, err := Generator(rnd, grammar)
+		assert.NoError(t, err)
+
+		bounded.MaxDepth = 2
+		bounded.MaxRepeat = 2
+
+		ohsnap.Check(t, 1000, bounded, func(input []rune) bool {
+			depth, maxDepth := 0, 0
+
+			for _, x := range input {
+				switch x {
+				case '[':
+					depth++
+					maxDepth = max(maxDepth, depth)
+				case ']':
+					depth--
+				}
+			}
+
+			_, err := Parse(input, grammar)
+			return err == nil && maxDepth <= 1
+		})
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		invalid := 0
+
+		for i := 0; i < 1000; i++ {
+			if _, err := Parse(g.GenerateInvalid(), grammar); err != nil {
+				invalid++
+			}
+		}
+
+		assert.Greater(t, invalid, 250)
+	})
+
+	t.Run("corpus", func(t *testing.T) {
+		corpus := g.Corpus(10)
+		assert.Len(t, corpus, 10)
+
+		for i := 0; i < len(corpus); i += 2 {
+			_, err := Parse(corpus[i], grammar)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("not described combinators", func(t *testing.T) {
+		c := Sequence(0, Eq("expected a", 'a'), func(buffer common.Buffer[rune, Position]) (rune, common.Error[Position]) {
+			return 'b', nil
+		})
+
+		_, err := Generator(rnd, c)
+		assert.EqualError(t, err, `input can't be generated by grammar "a" , ? unknown ?`)
+
+		_, err = Generator(rnd, Eq("expected ы", 'ы'))
+		assert.NoError(t, err)
+
+		_, err = Generator(rnd, Range("expected cyrillic", 'а', 'я'))
+		assert.EqualError(t, err, `input can't be generated by grammar ? "а" .. "я" ?`)
+
+		_, err = Generator(rnd, Range("expected cyrillic", 'а', 'я'), 'б')
+		assert.NoError(t, err)
+	})
+}
+
+func FuzzGenerator(f *testing.F) {
+	common.EnableDescriptions()
+	grammar := listGrammar()
+	g, err := Generator(rand.New(rand.NewPCG(0, 1)), grammar)
+	common.DisableDescriptions()
+
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, input := range g.Corpus(20) {
+		f.Add(string(input))
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		// parser must not panic on any input
+		_, _ = ParseString(input, grammar)
+	})
+}
@@ -0,0 +1,188 @@
+package strings
+
+import (
+	"sync/atomic"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Relocatable - result of combinator with positions,
+// which can be moved after edit of text (see Memo).
+// Relocate must return moved copy and keep original value unchanged.
+type Relocatable[T any] interface {
+	Relocate(move func(Position) Position) T
+}
+
+type memoKey struct {
+	id    uint64
+	index int
+}
+
+type memoEntry struct {
+	value any
+	err   common.Error[Position]
+	end   Position
+	// examined - index after the last rune examined by combinator
+	examined int
+	// move - change of positions of value after edits, nil if value is actual
+	move func(Position) Position
+}
+
+var memoID atomic.Uint64
+
+// Memo - memoize results of c combinator in buffer made by Buffer function
+// for incremental parsing. Results are kept after Edit of buffer
+// if runes examined by c combinator are not changed, so only invalidated
+// regions of text are parsed again. Positions of reused results placed
+// after edit are moved if results implement Relocatable interface,
+// like common.SyntaxNode and common.Located.
+// In other buffers (for example, inside Node) it runs c combinator as is.
+func Memo[T any](c common.Combinator[rune, Position, T]) common.Combinator[rune, Position, T] {
+	id := memoID.Add(1)
+
+	return func(buf common.Buffer[rune, Position]) (T, common.Error[Position]) {
+		b, ok := buf.(*buffer)
+		if !ok {
+			return c(buf)
+		}
+
+		return memoize(b, id, c)
+	}
+}
+
+func memoize[T any](
+	b *buffer,
+	id uint64,
+	c common.Combinator[rune, Position, T],
+) (T, common.Error[Position]) {
+	var null T
+
+	if b.memo == nil {
+		b.memo = make(map[memoKey]*memoEntry)
+	}
+
+	key := memoKey{id: id, index: b.position.index}
+
+	if entry, exists := b.memo[key]; exists {
+		b.position = entry.end
+		b.examine(entry.examined)
+
+		if entry.err != nil {
+			return null, entry.err
+		}
+
+		if entry.move != nil {
+			if x, ok := entry.value.(Relocatable[T]); ok {
+				entry.value = x.Relocate(entry.move)
+			}
+
+			entry.move = nil
+		}
+
+		result, _ := entry.value.(T)
+		return result, nil
+	}
+
+	outer := b.examined
+	b.examined = b.position.index
+
+	result, err := c(b)
+
+	b.memo[key] = &memoEntry{
+		value:    result,
+		err:      err,
+		end:      b.position,
+		examined: b.examined,
+	}
+
+	b.examine(outer)
+
+	return result, err
+}
+
+// Edit - replace runes in range [start, end) by text and seek to the beginning of buffer.
+// Results of Memo combinators which examined only runes outside of range
+// are reused by next parsing, other results are discarded.
+func (b *buffer) Edit(start, end int, text string) error {
+	if start < 0 || end < start || end > len(b.data) {
+		return common.ErrOutOfBounds
+	}
+
+	runes := []rune(text)
+	delta := len(runes) - (end - start)
+
+	oldEnd := b.positionOf(end)
+
+	data := make([]rune, 0, len(b.data)+delta)
+	data = append(data, b.data[:start]...)
+	data = append(data, runes...)
+	data = append(data, b.data[end:]...)
+	b.data = data
+
+	newEnd := b.positionOf(start + len(runes))
+
+	move := func(p Position) Position {
+		if p.index < end {
+			return p
+		}
+
+		if p.line == oldEnd.line {
+			p.column = p.column + newEnd.column - oldEnd.column
+		}
+
+		p.line = p.line + newEnd.line - oldEnd.line
+		p.index += delta
+
+		return p
+	}
+
+	memo := make(map[memoKey]*memoEntry, len(b.memo))
+
+	for key, entry := range b.memo {
+		switch {
+		case key.index >= end:
+			if entry.err != nil {
+				continue
+			}
+
+			if entry.move == nil {
+				entry.move = move
+			} else {
+				previous := entry.move
+				entry.move = func(p Position) Position {
+					return move(previous(p))
+				}
+			}
+
+			entry.end = move(entry.end)
+			entry.examined += delta
+			key.index += delta
+
+			memo[key] = entry
+		case entry.examined <= start:
+			memo[key] = entry
+		}
+	}
+
+	b.memo = memo
+	b.position = Position{}
+	b.examined = 0
+
+	return nil
+}
+
+// positionOf - position of rune with index in text.
+func (b *buffer) positionOf(index int) Position {
+	p := Position{index: index}
+
+	for _, x := range b.data[:index] {
+		if _, isNewLine := b.newLineRunes[x]; isNewLine {
+			p.column = 0
+			p.line++
+		} else {
+			p.column++
+		}
+	}
+
+	return p
+}
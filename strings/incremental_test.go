@@ -0,0 +1,155 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestMemo(t *testing.T) {
+	t.Parallel()
+
+	newIncrementalParser := func(calls *int) common.Combinator[rune, Position, common.Locations[string, Position]] {
+		word := Cast(
+			Some(0, "expected word", Try(Letter("expected letter"))),
+			func(x []rune) (string, error) {
+				*calls++
+				return string(x), nil
+			},
+		)
+
+		line := Memo(WithSpan(SkipAfter(Eq("expected new line", '\n'), word)))
+
+		return Cast(
+			Many(0, Try(line)),
+			func(x []common.Located[string, Position]) (common.Locations[string, Position], error) {
+				return common.Locations[string, Position](x), nil
+			},
+		)
+	}
+
+	t.Run("reuse results after edit", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		parser := newIncrementalParser(&calls)
+		buf := Buffer([]rune("foo\nbar\nbaz\nqux\n"))
+
+		result, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "bar", "baz", "qux"}, result.Values())
+		assert.Equal(t, 4, calls)
+
+		// replace "bar" by "hello\nworld"
+		assert.NoError(t, buf.Edit(4, 7, "hello\nworld"))
+
+		calls = 0
+		result, err = common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "hello", "world", "baz", "qux"}, result.Values())
+		assert.Equal(t, 2, calls)
+
+		expected, err := ParseString("foo\nhello\nworld\nbaz\nqux\n", parser)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.Equal(
+			t,
+			common.Span[Position]{
+				Start: Position{line: 3, index: 16},
+				End:   Position{line: 4, index: 20},
+			},
+			result[3].Position(),
+		)
+
+		// append to the last line
+		assert.NoError(t, buf.Edit(23, 23, "x"))
+
+		calls = 0
+		result, err = common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "hello", "world", "baz", "quxx"}, result.Values())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("edit inside of line", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		parser := newIncrementalParser(&calls)
+		buf := Buffer([]rune("ab\ncd\nef\n"))
+
+		_, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+
+		assert.NoError(t, buf.Edit(1, 1, "x"))
+
+		calls = 0
+		result, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"axb", "cd", "ef"}, result.Values())
+		assert.Equal(t, 1, calls)
+		assert.Equal(
+			t,
+			common.Span[Position]{
+				Start: Position{line: 2, index: 7},
+				End:   Position{line: 3, index: 10},
+			},
+			result[2].Position(),
+		)
+
+		// remove new line, the first line is parsed again
+		assert.NoError(t, buf.Edit(3, 4, "1"))
+
+		calls = 0
+		result, err = common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Values())
+		assert.Equal(t, 1, calls)
+
+		assert.NoError(t, buf.Edit(3, 4, "\n"))
+
+		calls = 0
+		result, err = common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"axb", "cd", "ef"}, result.Values())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("syntax tree", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		number := Cast(Some(0, "expected number", Try(Digit("expected digit"))), func(x []rune) (string, error) {
+			calls++
+			return string(x), nil
+		})
+		parser := Many(0, Try(Memo(Node("number", SkipAfter(Eq("expected space", ' '), number)))))
+
+		buf := Buffer([]rune("1 22 3 "))
+
+		_, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+
+		assert.NoError(t, buf.Edit(0, 1, "10"))
+
+		calls = 0
+		nodes, err := common.Parse(buf, parser)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, nodes, 3)
+		assert.Equal(t, "22 ", string(nodes[1].Items))
+		assert.Equal(t, Position{column: 3, index: 3}, nodes[1].Start)
+		assert.Equal(t, Position{column: 6, index: 6}, nodes[1].End)
+	})
+
+	t.Run("invalid edits", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]rune("abc"))
+		assert.ErrorIs(t, buf.Edit(-1, 1, ""), common.ErrOutOfBounds)
+		assert.ErrorIs(t, buf.Edit(2, 1, ""), common.ErrOutOfBounds)
+		assert.ErrorIs(t, buf.Edit(1, 4, ""), common.ErrOutOfBounds)
+	})
+}
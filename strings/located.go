@@ -0,0 +1,22 @@
+package strings
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// WithSpan - wrap result of c combinator with positions of buffer
+// before and after parsing.
+func WithSpan[T any](
+	c common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, common.Located[T, Position]] {
+	return common.WithSpan(c)
+}
+
+// SequenceWithSpan - like Sequence, but wrap results of each combinator
+// with positions, span of all results is returned by Position method.
+func SequenceWithSpan[T any](
+	cap int,
+	cs ...common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, common.Locations[T, Position]] {
+	return common.SequenceWithSpan(cap, cs...)
+}
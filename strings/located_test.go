@@ -0,0 +1,81 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestWithSpan(t *testing.T) {
+	t.Parallel()
+
+	word := Some(0, "expected word", Try(Letter("expected letter")))
+
+	text := Cast(word, func(x []rune) (string, error) {
+		return string(x), nil
+	})
+
+	runTests(t, []test[common.Located[string, Position]]{
+		{
+			comb: WithSpan(Skip(Eq("expected space", ' '), text)),
+			cases: []testCase[common.Located[string, Position]]{
+				{
+					input: " foo bar",
+					output: common.Located[string, Position]{
+						Value: "foo",
+						Span: common.Span[Position]{
+							Start: Position{},
+							End:   Position{column: 4, index: 4},
+						},
+					},
+				},
+				{
+					input:  "foo",
+					output: common.Located[string, Position]{},
+					err:    common.NewParseError(Position{}, "expected space"),
+				},
+			},
+		},
+	})
+
+	t.Run("sequence", func(t *testing.T) {
+		t.Parallel()
+
+		newLine := Cast(Eq("expected new line", '\n'), func(x rune) ([]rune, error) {
+			return []rune{x}, nil
+		})
+
+		result, err := ParseString("ab\ncd", SequenceWithSpan(0, word, newLine, word))
+		assert.NoError(t, err)
+		assert.Equal(t, [][]rune{[]rune("ab"), []rune("\n"), []rune("cd")}, result.Values())
+		assert.Equal(
+			t,
+			common.Span[Position]{
+				Start: Position{line: 1, index: 3},
+				End:   Position{line: 1, column: 2, index: 5},
+			},
+			result[2].Position(),
+		)
+		assert.Equal(
+			t,
+			common.Span[Position]{
+				End: Position{line: 1, column: 2, index: 5},
+			},
+			result.Position(),
+		)
+
+		located, err := ParseString("ab cd", Sequence(0, WithSpan(word), Skip(Eq("E", ' '), WithSpan(word))))
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			common.Span[Position]{
+				End: Position{column: 5, index: 5},
+			},
+			common.Locations[[]rune, Position](located).Position(),
+		)
+
+		assert.Equal(t, common.Span[Position]{}, common.Locations[rune, Position](nil).Position())
+	})
+}
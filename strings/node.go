@@ -0,0 +1,24 @@
+package strings
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// Node - wrap c combinator to produce node of syntax tree with name,
+// matched runes, positions and result of c combinator as value.
+// Nodes produced by Node combinators inside c become children of the node.
+func Node[T any](
+	name string,
+	c common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, *common.SyntaxNode[rune, Position]] {
+	return common.Node(name, c)
+}
+
+// Lossless - like Node, but produce lossless syntax tree with runes skipped
+// by Skip, SkipMany and Padded attached as trivia to token nodes (see common.Lossless).
+func Lossless[T any](
+	name string,
+	c common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, *common.SyntaxNode[rune, Position]] {
+	return common.Lossless(name, c)
+}
@@ -0,0 +1,258 @@
+package strings
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+type testVisitor struct {
+	events []string
+}
+
+func (v *testVisitor) Enter(node *common.SyntaxNode[rune, Position]) bool {
+	v.events = append(v.events, "enter "+node.Name)
+	return node.Name != "number"
+}
+
+func (v *testVisitor) Leave(node *common.SyntaxNode[rune, Position]) {
+	v.events = append(v.events, "leave "+node.Name)
+}
+
+func testSumNode() common.Combinator[rune, Position, *common.SyntaxNode[rune, Position]] {
+	digit := Node("digit", Digit("expected digit"))
+	number := Node("number", Some(0, "expected number", Try(digit)))
+	plus := Node("plus", Eq("expected +", '+'))
+
+	return Node(
+		"sum",
+		Sequence(
+			0,
+			Cast(number, func(n *common.SyntaxNode[rune, Position]) (any, error) { return n, nil }),
+			Cast(
+				Many(0, Try(Sequence(0, plus, number))),
+				func(x [][]*common.SyntaxNode[rune, Position]) (any, error) { return x, nil },
+			),
+		),
+	)
+}
+
+func TestNode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		node, err := ParseString("12+3+", testSumNode())
+		assert.NoError(t, err)
+
+		assert.Equal(t, "sum", node.Name)
+		assert.Equal(t, "12+3", string(node.Items))
+		assert.Equal(t, Position{}, node.Start)
+		assert.Equal(t, Position{column: 4, index: 4}, node.End)
+
+		names := make([]string, 0)
+		for _, child := range node.Children {
+			names = append(names, child.Name+":"+string(child.Items))
+		}
+
+		// nodes of failed '+' branch are discarded
+		assert.Equal(t, []string{"number:12", "plus:+", "number:3"}, names)
+
+		assert.Len(t, node.Children[0].Children, 2)
+		assert.Equal(t, '2', node.Children[0].Children[1].Value)
+		assert.Equal(t, Position{column: 1, index: 1}, node.Children[0].Children[1].Start)
+		assert.Equal(t, node.Children[0], node.Child("number"))
+		assert.Nil(t, node.Child("minus"))
+		assert.Len(t, node.Find("digit"), 3)
+	})
+
+	t.Run("walk and visit", func(t *testing.T) {
+		t.Parallel()
+
+		node, err := ParseString("1+2", testSumNode())
+		assert.NoError(t, err)
+
+		depths := make([]int, 0)
+		node.Walk(func(x *common.SyntaxNode[rune, Position], depth int) bool {
+			depths = append(depths, depth)
+			return x.Name != "number"
+		})
+		assert.Equal(t, []int{0, 1, 1, 1}, depths)
+
+		v := new(testVisitor)
+		common.Visit(node, v)
+		assert.Equal(t, []string{
+			"enter sum",
+			"enter number", "leave number",
+			"enter plus", "leave plus",
+			"enter number", "leave number",
+			"leave sum",
+		}, v.events)
+	})
+
+	t.Run("pretty print", func(t *testing.T) {
+		t.Parallel()
+
+		node, err := ParseString("1+2", testSumNode())
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`sum "1+2" [line=0 column=0 index=0 - line=0 column=3 index=3]
+  number "1" [line=0 column=0 index=0 - line=0 column=1 index=1]
+    digit "1" [line=0 column=0 index=0 - line=0 column=1 index=1]
+  plus "+" [line=0 column=1 index=1 - line=0 column=2 index=2]
+  number "2" [line=0 column=2 index=2 - line=0 column=3 index=3]
+    digit "2" [line=0 column=2 index=2 - line=0 column=3 index=3]
+`,
+			node.String(),
+		)
+
+		assert.EqualError(t, common.Fprint(failingWriter{}, node), "write failed")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseString("+", testSumNode())
+		assert.EqualError(t, err, "Parse error at line=0 column=0 index=0: expected number")
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestLossless(t *testing.T) {
+	t.Parallel()
+
+	comment := Skip(Eq("expected #", '#'), Many(0, Try(NoneOf("expected not new line", '\n'))))
+	space := Cast(Space("expected space"), func(x rune) ([]rune, error) {
+		return []rune{x}, nil
+	})
+	trivia := Choice("expected trivia", Try(space), Try(comment))
+
+	number := Node("number", Some(0, "expected number", Try(Digit("expected digit"))))
+	list := Lossless(
+		"list",
+		Between(
+			Padded(trivia, Eq("expected [", '[')),
+			SepBy(0, Padded(trivia, number), Eq("expected ,", ',')),
+			SkipMany(trivia, Eq("expected ]", ']')),
+		),
+	)
+
+	t.Run("trivia", func(t *testing.T) {
+		t.Parallel()
+
+		input := " [1 ,  22 # two\n, 3]\t"
+
+		node, err := ParseString(input, SkipAfter(trivia, list))
+		assert.NoError(t, err)
+		assert.Equal(t, input[:len(input)-1], string(node.Source()))
+		assert.Equal(t, input[:len(input)-1], string(node.Items))
+
+		tokens := make([]string, 0)
+		node.Walk(func(x *common.SyntaxNode[rune, Position], _ int) bool {
+			if len(x.Children) == 0 {
+				tokens = append(
+					tokens,
+					x.Name+":"+string(x.Leading)+"|"+string(x.Items)+"|"+string(x.Trailing),
+				)
+			}
+
+			return true
+		})
+
+		// trailing tab is consumed outside of lossless tree
+		assert.Equal(t, []string{
+			": |[|",
+			"number:|1|",
+			": |,|",
+			"number:  |22|",
+			": # two\n|,|",
+			"number: |3|",
+			":|]|",
+		}, tokens)
+
+		assert.Equal(t, Position{column: 7, index: 7}, node.Children[3].Start)
+		assert.Equal(t, Position{column: 9, index: 9}, node.Children[3].End)
+	})
+
+	t.Run("whole input", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{
+			"[]",
+			"  [ ]  ",
+			"#a\n[1,2] # b",
+			"[ 1\t,\n2 ,3\n]\n",
+		} {
+			node, err := ParseString(input, Lossless("file", Padded(trivia, list)))
+			assert.NoError(t, err)
+			assert.Equal(t, input, string(node.Source()), input)
+		}
+
+		node, err := ParseString("  ", Lossless("trivia", SkipMany(trivia, EOF())))
+		assert.NoError(t, err)
+		assert.Empty(t, node.Children)
+		assert.Empty(t, node.Items)
+		assert.Equal(t, "  ", string(node.Leading))
+		assert.Equal(t, "  ", string(node.Source()))
+	})
+
+	t.Run("edit and print", func(t *testing.T) {
+		t.Parallel()
+
+		node, err := ParseString("[1, # one\n 2 ]", list)
+		assert.NoError(t, err)
+
+		for _, x := range node.Find("number") {
+			x.Items = append(x.Items, '0')
+		}
+
+		assert.Equal(t, "[10, # one\n 20 ]", string(node.Source()))
+		assert.Equal(t, "[1, # one\n 2 ]", string(node.Items))
+	})
+
+	t.Run("wrapped buffers", func(t *testing.T) {
+		t.Parallel()
+
+		pair := Lossless("pair", common.Isolate(
+			"expected 6 runes",
+			6,
+			Sequence(0, Padded(trivia, number), Padded(trivia, number)),
+		))
+
+		node, err := ParseString(" 1  2 ", pair)
+		assert.NoError(t, err)
+		assert.Equal(t, " 1  2 ", string(node.Source()))
+
+		if assert.Len(t, node.Children, 2) {
+			assert.Equal(t, "number", node.Children[0].Name)
+			assert.Equal(t, " ", string(node.Children[0].Leading))
+			assert.Equal(t, "1", string(node.Children[0].Items))
+			assert.Equal(t, "  ", string(node.Children[1].Leading))
+			assert.Equal(t, " ", string(node.Children[1].Trailing))
+		}
+	})
+
+	t.Run("backtracking", func(t *testing.T) {
+		t.Parallel()
+
+		first := Sequence(0, Padded(trivia, number), Padded(trivia, Node("end", Eq("expected ;", ';'))))
+		second := Sequence(0, Padded(trivia, number), Padded(trivia, Node("end", Eq("expected .", '.'))))
+
+		node, err := ParseString(" 1 .", Lossless("statement", Choice("expected statement", Try(first), second)))
+		assert.NoError(t, err)
+		assert.Len(t, node.Children, 2)
+		assert.Equal(t, " ", string(node.Children[0].Leading))
+		assert.Equal(t, " ", string(node.Children[1].Leading))
+		assert.Equal(t, " 1 .", string(node.Source()))
+	})
+}
@@ -0,0 +1,148 @@
+package strings
+
+import (
+	"fmt"
+	stdstrings "strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestParseParallel(t *testing.T) {
+	t.Parallel()
+
+	newLine := Eq("expected new line", '\n')
+
+	// record - key=value
+	record := Sequence(
+		0,
+		Cast(Some(1, "expected key", Try(Letter("expected letter"))), func(x []rune) (any, error) {
+			return string(x), nil
+		}),
+		Cast(Skip(Eq("expected =", '='), Unsigned[int]()), func(x int) (any, error) {
+			return x, nil
+		}),
+	)
+
+	lines := make([]string, 1000)
+	expected := make([][]any, len(lines))
+
+	for i := range lines {
+		lines[i] = fmt.Sprintf("key=%d", i)
+		expected[i] = []any{"key", i}
+	}
+
+	t.Run("order of results", func(t *testing.T) {
+		t.Parallel()
+
+		for _, workers := range []int{0, 1, 3, 16} {
+			result, err := ParseParallel([]rune(stdstrings.Join(lines, "\n")+"\n"), workers, newLine, record)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, result)
+		}
+	})
+
+	t.Run("global positions of errors", func(t *testing.T) {
+		t.Parallel()
+
+		broken := append([]string{}, lines...)
+		broken[500] = "key=x"
+		broken[700] = "key=1 "
+
+		result, err := ParseParallel([]rune(stdstrings.Join(broken, "\n")), 4, newLine, record)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "Parse error at line=500 column=4 index=3894: digit")
+
+		chunks := ParseChunks([]rune(stdstrings.Join(broken, "\n")), 4, newLine, record)
+		assert.Len(t, chunks, len(lines))
+
+		failed := make([]string, 0)
+		for i, x := range chunks {
+			if x.Err != nil {
+				failed = append(failed, fmt.Sprintf("%d: %v", i, x.Err))
+				continue
+			}
+
+			assert.Equal(t, expected[i], x.Value)
+		}
+
+		assert.Equal(t, []string{
+			"500: Parse error at line=500 column=4 index=3894: digit",
+			"700: Parse error at line=700 column=5 index=5493: expected 6 items of chunk to be consumed, actual 5",
+		}, failed)
+
+		assert.Equal(t, Position{line: 700, index: 5488}, chunks[700].Start)
+		assert.Equal(t, Position{line: 700, column: 6, index: 5494}, chunks[700].End)
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		t.Parallel()
+
+		chunks := ParseChunks([]rune("a=1\r\nbb=22\r\n"), 2, SequenceOf("expected CRLF", '\r', '\n'), Node("record", record))
+		assert.Len(t, chunks, 2)
+
+		assert.NoError(t, chunks[1].Err)
+		assert.Equal(t, "bb=22", string(chunks[1].Value.Items))
+		assert.Equal(t, Position{line: 1, index: 5}, chunks[1].Value.Start)
+		assert.Equal(t, Position{line: 1, column: 5, index: 10}, chunks[1].Value.End)
+	})
+
+	t.Run("empty chunks", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := ParseParallel([]rune(""), 2, newLine, record)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+
+		// adjacent boundaries
+		chunks := ParseChunks([]rune("\na=1\n\n\nb=2\n\n"), 2, newLine, record)
+		assert.Equal(t, []common.Chunk[Position, []any]{
+			{
+				Start: Position{line: 1, index: 1},
+				End:   Position{line: 1, column: 3, index: 4},
+				Value: []any{"a", 1},
+			},
+			{
+				Start: Position{line: 4, index: 7},
+				End:   Position{line: 4, column: 3, index: 10},
+				Value: []any{"b", 2},
+			},
+		}, chunks)
+
+		result, err = ParseParallel([]rune("\n\n\n"), 2, newLine, record)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+
+		many := ParseChunks([]rune("1\n2\n3"), 2, newLine, Many(0, Try(Digit("expected digit"))))
+		assert.Equal(t, []common.Chunk[Position, []rune]{
+			{Start: Position{}, End: Position{column: 1, index: 1}, Value: []rune("1")},
+			{Start: Position{line: 1, index: 2}, End: Position{line: 1, column: 1, index: 3}, Value: []rune("2")},
+			{Start: Position{line: 2, index: 4}, End: Position{line: 2, column: 1, index: 5}, Value: []rune("3")},
+		}, many)
+	})
+}
+
+func BenchmarkParseParallel(b *testing.B) {
+	lines := make([]string, 10000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%d,%d,%d", i, i*2, i*3)
+	}
+
+	data := []rune(stdstrings.Join(lines, "\n"))
+	newLine := Eq("expected new line", '\n')
+	record := SepBy1(3, "expected numbers", Unsigned[int](), Eq("expected comma", ','))
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseParallel(data, 1, newLine, record)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseParallel(data, 0, newLine, record)
+		}
+	})
+}
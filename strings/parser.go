@@ -1,6 +1,9 @@
 package strings
 
 import (
+	"io"
+	"iter"
+
 	"github.com/okneniz/parsec/common"
 )
 
@@ -20,3 +23,53 @@ func ParseString[T any](
 ) (T, common.Error[Position]) {
 	return Parse([]rune(str), parse)
 }
+
+// ParseSeq - parse records from reader by c combinator until end of text,
+// yielding each record as soon as it's parsed (see common.ParseSeq).
+// Only unparsed part of text is kept in memory.
+func ParseSeq[T any](
+	r io.Reader,
+	parse common.Combinator[rune, Position, T],
+) iter.Seq2[T, common.Error[Position]] {
+	return common.ParseSeq(BufferFromReader(r), parse)
+}
+
+// ParseAll - like ParseSeq, but records are separated by sep combinator,
+// for example by newline (see common.ParseAll).
+func ParseAll[T any, B any](
+	r io.Reader,
+	sep common.Combinator[rune, Position, B],
+	parse common.Combinator[rune, Position, T],
+) iter.Seq2[T, common.Error[Position]] {
+	return common.ParseAll(BufferFromReader(r), sep, parse)
+}
+
+// ParseParallel - split text to chunks by boundary combinator (for example, newline)
+// and parse chunks by c combinator concurrently in count of workers goroutines
+// (see common.ParseParallel). Returns results in input order
+// or error of the first failed chunk with position in the whole text.
+func ParseParallel[T any, B any](
+	data []rune,
+	workers int,
+	boundary common.Combinator[rune, Position, B],
+	parse common.Combinator[rune, Position, T],
+) ([]T, common.Error[Position]) {
+	return common.ParseParallel(bufferOf(data), workers, boundary, parse)
+}
+
+// ParseChunks - like ParseParallel, but returns results and errors
+// of all chunks in input order (see common.ParseChunks).
+func ParseChunks[T any, B any](
+	data []rune,
+	workers int,
+	boundary common.Combinator[rune, Position, B],
+	parse common.Combinator[rune, Position, T],
+) []common.Chunk[Position, T] {
+	return common.ParseChunks(bufferOf(data), workers, boundary, parse)
+}
+
+func bufferOf(data []rune) func() common.Buffer[rune, Position] {
+	return func() common.Buffer[rune, Position] {
+		return Buffer(data)
+	}
+}
@@ -21,6 +21,11 @@ func (p Position) Column() uint {
 	return p.column
 }
 
+// Index - index of rune in text.
+func (p Position) Index() int {
+	return p.index
+}
+
 // String - return string representation of opsition.
 func (p Position) String() string {
 	return fmt.Sprintf("line=%d column=%d index=%d", p.line, p.column, p.index)
@@ -0,0 +1,118 @@
+package strings
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// tests of profiler are not parallel, because tracing is enabled globally
+func TestProfiler(t *testing.T) {
+	digit := Named("digit", Digit("expected digit"))
+	number := Named("number", Some(0, "expected number", Try(digit)))
+	word := Named("word", Some(0, "expected word", Try(Letter("expected letter"))))
+	item := Named("item", Choice("expected item", Try(Sequence(0, number, word)), Sequence(0, number)))
+	items := SepBy(0, item, Eq("expected ,", ','))
+
+	profiler := common.NewProfiler()
+	common.EnableTracing(profiler)
+	defer common.DisableTracing()
+
+	result, err := ParseString("12a,345,6", items)
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+
+	profiles := profiler.Profiles()
+	assert.Len(t, profiles, 4)
+
+	byName := make(map[string]common.RuleProfile)
+	for _, x := range profiles {
+		assert.GreaterOrEqual(t, x.Total, x.Self)
+		x.Total, x.Self = 0, 0
+		byName[x.Name] = x
+	}
+
+	assert.Equal(t, common.RuleProfile{
+		Name:      "item",
+		Calls:     3,
+		Successes: 3,
+		Consumed:  7,
+	}, byName["item"])
+
+	// number is parsed again after failed sequence
+	assert.Equal(t, common.RuleProfile{
+		Name:      "number",
+		Calls:     5,
+		Successes: 5,
+		Consumed:  10,
+	}, byName["number"])
+
+	assert.Equal(t, common.RuleProfile{
+		Name:        "word",
+		Calls:       3,
+		Successes:   1,
+		Failures:    2,
+		Consumed:    1,
+		Backtracked: 0,
+	}, byName["word"])
+
+	// digit consumes rune on failure
+	assert.Equal(t, common.RuleProfile{
+		Name:        "digit",
+		Calls:       13,
+		Successes:   10,
+		Failures:    3,
+		Consumed:    10,
+		Backtracked: 3,
+	}, byName["digit"])
+
+	t.Run("report", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, profiler.Report(out))
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		assert.Len(t, lines, 5)
+		assert.Regexp(
+			t,
+			regexp.MustCompile(`^name\s+calls\s+successes\s+failures\s+total\s+self\s+consumed\s+backtracked$`),
+			string(lines[0]),
+		)
+		assert.Regexp(t, regexp.MustCompile(`^word\s+3\s+1\s+2\s+\S+\s+\S+\s+1\s+0$`), findLine(lines, "word"))
+	})
+
+	t.Run("pprof", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, profiler.WritePprof(out))
+
+		r, err := gzip.NewReader(out)
+		assert.NoError(t, err)
+
+		data, err := io.ReadAll(r)
+		assert.NoError(t, err)
+
+		for _, s := range []string{"calls", "count", "self", "nanoseconds", "item", "number", "word", "digit"} {
+			assert.Contains(t, string(data), s)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		profiler.Reset()
+		assert.Empty(t, profiler.Profiles())
+	})
+}
+
+func findLine(lines [][]byte, prefix string) string {
+	for _, line := range lines {
+		if bytes.HasPrefix(line, []byte(prefix+" ")) {
+			return string(line)
+		}
+	}
+
+	return ""
+}
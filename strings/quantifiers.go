@@ -43,3 +43,31 @@ func Count[T any](
 ) common.Combinator[rune, Position, []T] {
 	return common.Count[rune, Position, T](cap, errMessage, c)
 }
+
+// ManyFold - like Many, but combine data which returned by c combinator
+// with accumulator by f function instead of accumulation of slice,
+// starting with init value (see common.ManyFold).
+func ManyFold[T any, A any](
+	init A,
+	c common.Combinator[rune, Position, T],
+	f func(A, T) A,
+) common.Combinator[rune, Position, A] {
+	return common.ManyFold(init, c, f)
+}
+
+// CountOnly - count data which returned by c consumer until it possible,
+// results are discarded. Stop on first error or end of buffer.
+func CountOnly[T any](c common.Combinator[rune, Position, T]) common.Combinator[rune, Position, int] {
+	return common.CountOnly(c)
+}
+
+// SkipCount - skip exactly n occurrences of data parsed by skip combinator
+// before body combinator, results of skip are discarded (see common.SkipCount).
+func SkipCount[T any, S any](
+	n int,
+	errMessage string,
+	skip common.Combinator[rune, Position, S],
+	body common.Combinator[rune, Position, T],
+) common.Combinator[rune, Position, T] {
+	return common.SkipCount(n, errMessage, skip, body)
+}
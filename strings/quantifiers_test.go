@@ -1,8 +1,11 @@
 package strings
 
 import (
+	stdstrings "strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/okneniz/parsec/common"
 )
 
@@ -210,3 +213,220 @@ func TestCount(t *testing.T) {
 		},
 	})
 }
+
+func TestManyFold(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc int, x rune) int { return acc + int(x-'0') }
+
+	runTests(t, []test[int]{
+		{
+			comb: ManyFold(0, Try(Digit("expected digit")), sum),
+			cases: []testCase[int]{
+				{
+					input:  "",
+					output: 0,
+				},
+				{
+					input:  "1",
+					output: 1,
+				},
+				{
+					input:  "123",
+					output: 6,
+				},
+				{
+					input:  "12a3",
+					output: 3,
+				},
+				{
+					input:  "a123",
+					output: 0,
+				},
+			},
+		},
+	})
+}
+
+func TestCountOnly(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[int]{
+		{
+			comb: CountOnly(Try(Eq("expected 'a'", 'a'))),
+			cases: []testCase[int]{
+				{
+					input:  "",
+					output: 0,
+				},
+				{
+					input:  "aaa",
+					output: 3,
+				},
+				{
+					input:  "aab",
+					output: 2,
+				},
+				{
+					input:  "baa",
+					output: 0,
+				},
+			},
+		},
+	})
+}
+
+func TestSkipCount(t *testing.T) {
+	t.Parallel()
+
+	runTests(t, []test[rune]{
+		{
+			comb: SkipCount(2, "expected 'aa'", Eq("expected 'a'", 'a'), Eq("expected 'b'", 'b')),
+			cases: []testCase[rune]{
+				{
+					input:  "aab",
+					output: 'b',
+				},
+				{
+					input: "ab",
+					err: common.NewParseError(
+						Position{
+							line:   0,
+							column: 0,
+							index:  0,
+						},
+						"expected 'aa'",
+					),
+				},
+				{
+					input: "aaab",
+					err: common.NewParseError(
+						Position{
+							line:   0,
+							column: 2,
+							index:  2,
+						},
+						"expected 'b'",
+					),
+				},
+				{
+					input: "",
+					err: common.NewParseError(
+						Position{
+							line:   0,
+							column: 0,
+							index:  0,
+						},
+						"expected 'aa'",
+					),
+				},
+			},
+		},
+		{
+			comb: SkipCount(0, "expected nothing", Eq("expected 'a'", 'a'), Eq("expected 'b'", 'b')),
+			cases: []testCase[rune]{
+				{
+					input:  "b",
+					output: 'b',
+				},
+			},
+		},
+	})
+}
+
+// TestFoldAllocations - folding combinators allocate the same memory
+// for any count of items, only error of the last failed item is allocated.
+// Test is not parallel, because allocations are counted globally.
+func TestFoldAllocations(t *testing.T) {
+	digit := Try(Digit("expected digit"))
+	comma := Eq("expected comma", ',')
+	sum := func(acc int, x rune) int { return acc + int(x-'0') }
+
+	digits := func(n int) string { return stdstrings.Repeat("1", n) + ";" }
+	list := func(n int) string { return stdstrings.TrimSuffix(stdstrings.Repeat("1,", n), ",") + ";" }
+
+	examples := map[string]func(n int) (common.Combinator[rune, Position, int], string){
+		"ManyFold": func(n int) (common.Combinator[rune, Position, int], string) {
+			return ManyFold(0, digit, sum), digits(n)
+		},
+		"SepByFold": func(n int) (common.Combinator[rune, Position, int], string) {
+			return SepByFold(0, digit, comma, sum), list(n)
+		},
+		"CountOnly": func(n int) (common.Combinator[rune, Position, int], string) {
+			return CountOnly(digit), digits(n)
+		},
+		"SkipCount": func(n int) (common.Combinator[rune, Position, int], string) {
+			end := Cast(Eq("expected end", ';'), func(rune) (int, error) { return 0, nil })
+			return SkipCount(n, "expected digits", digit, end), digits(n)
+		},
+	}
+
+	allocs := func(n int, example func(n int) (common.Combinator[rune, Position, int], string)) float64 {
+		c, input := example(n)
+		buf := Buffer([]rune(input))
+
+		return testing.AllocsPerRun(100, func() {
+			_ = buf.Seek(Position{})
+
+			if _, err := c(buf); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	for name, example := range examples {
+		assert.Equal(t, allocs(10, example), allocs(1000, example), name)
+		assert.LessOrEqual(t, allocs(1000, example), float64(1), name)
+	}
+}
+
+func BenchmarkFold(b *testing.B) {
+	digit := Try(Digit("expected digit"))
+	comma := Eq("expected comma", ',')
+	sum := func(acc int, x rune) int { return acc + int(x-'0') }
+
+	digits := []rune(stdstrings.Repeat("1", 1000) + ";")
+	list := []rune(stdstrings.TrimSuffix(stdstrings.Repeat("1,", 1000), ",") + ";")
+
+	benchmark := func(b *testing.B, c common.Combinator[rune, Position, int], input []rune) {
+		buf := Buffer(input)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = buf.Seek(Position{})
+			_, _ = c(buf)
+		}
+	}
+
+	total := func(xs []rune) (int, error) { return len(xs), nil }
+
+	b.Run("Many", func(b *testing.B) {
+		benchmark(b, Cast(Many(0, digit), total), digits)
+	})
+
+	b.Run("ManyFold", func(b *testing.B) {
+		benchmark(b, ManyFold(0, digit, sum), digits)
+	})
+
+	b.Run("CountOnly", func(b *testing.B) {
+		benchmark(b, CountOnly(digit), digits)
+	})
+
+	b.Run("SepBy", func(b *testing.B) {
+		benchmark(b, Cast(SepBy(0, digit, comma), total), list)
+	})
+
+	b.Run("SepByFold", func(b *testing.B) {
+		benchmark(b, SepByFold(0, digit, comma, sum), list)
+	})
+
+	b.Run("Count", func(b *testing.B) {
+		benchmark(b, Cast(Count(1000, "expected digits", digit), total), digits)
+	})
+
+	b.Run("SkipCount", func(b *testing.B) {
+		end := Cast(Eq("expected end", ';'), func(rune) (int, error) { return 0, nil })
+		benchmark(b, SkipCount(1000, "expected digits", digit, end), digits)
+	})
+}
@@ -0,0 +1,116 @@
+package strings
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/okneniz/parsec/common"
+)
+
+type readerBuffer struct {
+	reader *bufio.Reader
+	// data - window of input which is kept in memory
+	data []rune
+	// offset - index of the first rune of window
+	offset       int
+	position     Position
+	newLineRunes map[rune]struct{}
+	eof          bool
+	err          error
+}
+
+var (
+	_ common.Buffer[rune, Position] = new(readerBuffer)
+	_ common.Releaser               = new(readerBuffer)
+	_ common.ReadErrorer            = new(readerBuffer)
+)
+
+// Read - read next item, if greedy buffer keep position after reading.
+func (b *readerBuffer) Read(greedy bool) (rune, error) {
+	if b.IsEOF() {
+		return 0, common.ErrEndOfFile
+	}
+
+	x := b.data[b.position.index-b.offset]
+
+	if greedy {
+		b.position.index++
+
+		if _, isNewLine := b.newLineRunes[x]; isNewLine {
+			b.position.column = 0
+			b.position.line++
+		} else {
+			b.position.column++
+		}
+	}
+
+	return x, nil
+}
+
+// Seek - change buffer position,
+// only positions of window after the last release are allowed.
+func (b *readerBuffer) Seek(x Position) error {
+	if b.position.index == x.index {
+		return nil
+	}
+
+	if x.index < b.offset || x.index >= b.offset+len(b.data) {
+		return common.ErrOutOfBounds
+	}
+
+	b.position = x
+	return nil
+}
+
+// Position - return current buffer position
+func (b *readerBuffer) Position() Position {
+	return b.position
+}
+
+// IsEOF - true if reader ended or failed.
+func (b *readerBuffer) IsEOF() bool {
+	for b.position.index-b.offset >= len(b.data) {
+		if b.eof {
+			return true
+		}
+
+		x, _, err := b.reader.ReadRune()
+		if err != nil {
+			b.eof = true
+
+			if err != io.EOF {
+				b.err = err
+			}
+
+			continue
+		}
+
+		b.data = append(b.data, x)
+	}
+
+	return false
+}
+
+// Release - drop runes before current position from memory.
+func (b *readerBuffer) Release() {
+	n := copy(b.data, b.data[b.position.index-b.offset:])
+	b.data = b.data[:n]
+	b.offset = b.position.index
+}
+
+// ReadErr - error of reader, nil if reader ended by io.EOF.
+func (b *readerBuffer) ReadErr() error {
+	return b.err
+}
+
+// BufferFromReader - make buffer which reads UTF-8 text from reader on demand
+// and use struct for positions. Read runes are kept in memory until release
+// (see common.Releaser), so it's useful with common.ParseSeq for streams.
+func BufferFromReader(r io.Reader, newLineRunes ...rune) *readerBuffer {
+	b := new(readerBuffer)
+	b.reader = bufio.NewReader(r)
+	b.data = make([]rune, 0, 64)
+	b.newLineRunes = Buffer(nil, newLineRunes...).newLineRunes
+
+	return b
+}
@@ -0,0 +1,54 @@
+package strings
+
+import (
+	"regexp"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// Regex - compile regular expression (RE2 syntax) to combinator
+// which matches it at the current position.
+// Returns the match and capture groups, like regexp.FindStringSubmatch,
+// groups which did not participate in the match are empty.
+// If it falls, it returns buffer to the previous position.
+// Returns error if pattern can't be compiled.
+func Regex(
+	errMessage string,
+	pattern string,
+) (common.Combinator[rune, Position, []string], error) {
+	re, err := regexp.Compile(`\A(?:` + pattern + `)`)
+	if err != nil {
+		return nil, err
+	}
+
+	parse := common.Regex[rune, Position](errMessage, re, func(x rune) rune {
+		return x
+	})
+
+	return func(buffer common.Buffer[rune, Position]) ([]string, common.Error[Position]) {
+		groups, err := parse(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(groups))
+		for i, group := range groups {
+			result[i] = string(group)
+		}
+
+		return result, nil
+	}, nil
+}
+
+// MustRegex - like Regex but panics if pattern can't be compiled.
+func MustRegex(
+	errMessage string,
+	pattern string,
+) common.Combinator[rune, Position, []string] {
+	c, err := Regex(errMessage, pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
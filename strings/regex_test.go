@@ -0,0 +1,93 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestRegex(t *testing.T) {
+	t.Parallel()
+
+	runTestsString(t, []test[[]string]{
+		{
+			comb: MustRegex("expected email", `([\w.]+)@([\w.]+)`),
+			cases: []testCase[[]string]{
+				{
+					input:  "john.doe@example.com, other",
+					output: []string{"john.doe@example.com", "john.doe", "example.com"},
+				},
+				{
+					input:  " john@example.com",
+					output: nil,
+					err:    common.NewParseError(Position{}, "expected email"),
+				},
+				{
+					input:  "",
+					output: nil,
+					err:    common.NewParseError(Position{}, "expected email"),
+				},
+			},
+		},
+		{
+			comb: MustRegex("expected word", `(\p{L}+)(-\p{L}+)?`),
+			cases: []testCase[[]string]{
+				{
+					input:  "привет мир",
+					output: []string{"привет", "привет", ""},
+				},
+				{
+					input:  "çà-và",
+					output: []string{"çà-và", "çà", "-và"},
+				},
+			},
+		},
+	})
+
+	t.Run("keep position after match", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Sequence(
+			0,
+			MustRegex("expected number", `\d+`),
+			Skip(Eq("expected new line", '\n'), MustRegex("expected word", `[a-zа-я]+`)),
+		)
+
+		result, err := ParseString("42\nслово", comb)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"42"}, {"слово"}}, result)
+
+		buf := Buffer([]rune("ab\ncd"))
+
+		_, err = MustRegex("expected letters", `(?s)ab.c`)(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, Position{line: 1, column: 1, index: 4}, buf.Position())
+
+		_, err = MustRegex("expected letters", `d+`)(buf)
+		assert.NoError(t, err)
+		assert.True(t, buf.IsEOF())
+	})
+
+	t.Run("restore position on failure", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]rune("aaab"))
+
+		_, err := MustRegex("expected a", `a+c`)(buf)
+		assert.EqualError(t, err, "Parse error at line=0 column=0 index=0: expected a")
+		assert.Equal(t, Position{}, buf.Position())
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Regex("E", `(a`)
+		assert.ErrorContains(t, err, "missing closing )")
+
+		assert.Panics(t, func() {
+			MustRegex("E", `[z-a]`)
+		})
+	})
+}
@@ -0,0 +1,192 @@
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	stdstrings "strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+// countingReader - reader which counts read bytes.
+type countingReader struct {
+	reader io.Reader
+	count  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += n
+	return n, err
+}
+
+func TestParseSeq(t *testing.T) {
+	t.Parallel()
+
+	newLine := Eq("expected new line", '\n')
+
+	// record - key=value or key=[value]
+	record := Sequence(
+		0,
+		Cast(Some(1, "expected key", Try(Letter("expected letter"))), func(x []rune) (any, error) {
+			return string(x), nil
+		}),
+		Cast(Skip(Eq("expected =", '='), Choice(
+			"expected value",
+			Try(Unsigned[int]()),
+			Try(Between(Eq("expected [", '['), Unsigned[int](), Eq("expected ]", ']'))),
+		)), func(x int) (any, error) {
+			return x, nil
+		}),
+	)
+
+	collect := func(seq iter.Seq2[[]any, common.Error[Position]]) ([][]any, []string) {
+		values := make([][]any, 0)
+		errs := make([]string, 0)
+
+		for x, err := range seq {
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+
+			values = append(values, x)
+		}
+
+		return values, errs
+	}
+
+	t.Run("records", func(t *testing.T) {
+		t.Parallel()
+
+		values, errs := collect(ParseAll(stdstrings.NewReader("a=1\nb=[2]\nc=3\n"), newLine, record))
+		assert.Equal(t, [][]any{{"a", 1}, {"b", 2}, {"c", 3}}, values)
+		assert.Empty(t, errs)
+
+		values, errs = collect(ParseAll(stdstrings.NewReader("a=1\nb=2"), newLine, record))
+		assert.Equal(t, [][]any{{"a", 1}, {"b", 2}}, values)
+		assert.Empty(t, errs)
+
+		values, errs = collect(ParseAll(stdstrings.NewReader(""), newLine, record))
+		assert.Empty(t, values)
+		assert.Empty(t, errs)
+
+		values, errs = collect(ParseSeq(stdstrings.NewReader("a=1b=[2]"), record))
+		assert.Equal(t, [][]any{{"a", 1}, {"b", 2}}, values)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		values, errs := collect(ParseAll(stdstrings.NewReader("a=1\nb=[x]\nc=3\n"), newLine, record))
+		assert.Equal(t, [][]any{{"a", 1}}, values)
+		assert.Equal(t, []string{"Parse error at line=1 column=2 index=6: expected value"}, errs)
+
+		values, errs = collect(ParseAll(stdstrings.NewReader("a=1 b=2"), newLine, record))
+		assert.Equal(t, [][]any{{"a", 1}}, values)
+		assert.Equal(t, []string{"Parse error at line=0 column=3 index=3: expected new line"}, errs)
+
+		reader := io.MultiReader(stdstrings.NewReader("a=1\nb="), iotest.ErrReader(errors.New("connection reset")))
+		values, errs = collect(ParseAll(reader, newLine, record))
+		assert.Equal(t, [][]any{{"a", 1}}, values)
+		assert.Equal(t, []string{"Parse error at line=1 column=2 index=6: connection reset"}, errs)
+
+		digits := ParseSeq(stdstrings.NewReader("x"), Many(0, Try(Digit("expected digit"))))
+		for _, err := range digits {
+			assert.EqualError(t, err, "Parse error at line=0 column=0 index=0: record combinator doesn't consume input")
+		}
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		t.Parallel()
+
+		lines := make([]string, 10000)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("key=%d", i)
+		}
+
+		reader := &countingReader{reader: stdstrings.NewReader(stdstrings.Join(lines, "\n"))}
+		buf := BufferFromReader(reader)
+
+		window := 0
+		count := 0
+
+		for x, err := range common.ParseAll(buf, newLine, record) {
+			assert.NoError(t, err)
+			assert.Equal(t, []any{"key", count}, x)
+
+			window = max(window, cap(buf.data))
+			count++
+
+			if count == 5000 {
+				break
+			}
+		}
+
+		assert.Equal(t, 5000, count)
+		assert.LessOrEqual(t, window, 64)
+		assert.Less(t, reader.count, len(stdstrings.Join(lines, "\n")))
+	})
+
+	t.Run("in memory buffer", func(t *testing.T) {
+		t.Parallel()
+
+		values := make([][]any, 0)
+		for x, err := range common.ParseAll(Buffer([]rune("a=1\nb=2")), newLine, record) {
+			assert.NoError(t, err)
+			values = append(values, x)
+		}
+
+		assert.Equal(t, [][]any{{"a", 1}, {"b", 2}}, values)
+	})
+}
+
+func TestBufferFromReader(t *testing.T) {
+	t.Parallel()
+
+	buf := BufferFromReader(stdstrings.NewReader("ab\nвг"))
+
+	x, err := buf.Read(true)
+	assert.NoError(t, err)
+	assert.Equal(t, 'a', x)
+
+	start := buf.Position()
+
+	for _, expected := range []rune("b\nв") {
+		x, err = buf.Read(true)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, x)
+	}
+
+	assert.Equal(t, Position{line: 1, column: 1, index: 4}, buf.Position())
+	assert.NoError(t, buf.Seek(start))
+	assert.Equal(t, Position{column: 1, index: 1}, buf.Position())
+
+	buf.Release()
+	assert.ErrorIs(t, buf.Seek(Position{}), common.ErrOutOfBounds)
+
+	x, err = buf.Read(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 'b', x)
+
+	assert.NoError(t, buf.Seek(Position{line: 1, index: 3}))
+
+	for _, expected := range []rune("вг") {
+		x, err = buf.Read(true)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, x)
+	}
+
+	assert.True(t, buf.IsEOF())
+	assert.NoError(t, buf.ReadErr())
+
+	_, err = buf.Read(true)
+	assert.ErrorIs(t, err, common.ErrEndOfFile)
+}
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+type buffer[T any, P any] struct {
+	data     []T
+	index    int
+	position func(index int) P
+	indexOf  func(position P) int
+}
+
+var _ common.Buffer[string, int] = new(buffer[string, int])
+
+// Read - read next token, if greedy buffer keep position after reading.
+func (b *buffer[T, P]) Read(greedy bool) (T, error) {
+	if b.IsEOF() {
+		var null T
+		return null, common.ErrEndOfFile
+	}
+
+	x := b.data[b.index]
+
+	if greedy {
+		b.index++
+	}
+
+	return x, nil
+}
+
+// Seek - change buffer position
+// change nothing if you try to seek to the same position
+func (b *buffer[T, P]) Seek(position P) error {
+	x := b.indexOf(position)
+
+	if b.index == x {
+		return nil
+	}
+
+	if x < 0 {
+		return common.ErrOutOfBounds
+	}
+
+	if x >= len(b.data) {
+		return common.ErrOutOfBounds
+	}
+
+	b.index = x
+	return nil
+}
+
+// Position - return current buffer position
+func (b *buffer[T, P]) Position() P {
+	return b.position(b.index)
+}
+
+// IsEOF - true if buffer ended.
+func (b *buffer[T, P]) IsEOF() bool {
+	return b.index >= len(b.data)
+}
+
+// Buffer - make buffer which can read tokens on input and use
+// index of token for positions.
+func Buffer[T any](data []T) *buffer[T, int] {
+	b := new(buffer[T, int])
+	b.data = data
+	b.index = 0
+	b.position = func(index int) int { return index }
+	b.indexOf = func(index int) int { return index }
+	return b
+}
+
+// BufferWithSpans - make buffer which can read tokens on input and use
+// index and span of current token for positions, span is taken from token by span function.
+// At the end of buffer span of the last token is used.
+func BufferWithSpans[T any, S any](data []T, span func(T) S) *buffer[T, Position[S]] {
+	b := new(buffer[T, Position[S]])
+	b.data = data
+	b.index = 0
+	b.position = func(index int) Position[S] {
+		pos := Position[S]{index: index}
+
+		switch {
+		case index < len(data):
+			pos.span = span(data[index])
+		case len(data) > 0:
+			pos.span = span(data[len(data)-1])
+		}
+
+		return pos
+	}
+	b.indexOf = func(pos Position[S]) int { return pos.index }
+	return b
+}
@@ -0,0 +1,96 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+func TestBuffer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("index positions", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]string{"a", "b"})
+		assert.Equal(t, 0, buf.Position())
+		assert.False(t, buf.IsEOF())
+
+		x, err := buf.Read(false)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", x)
+		assert.Equal(t, 0, buf.Position())
+
+		x, err = buf.Read(true)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", x)
+		assert.Equal(t, 1, buf.Position())
+
+		x, err = buf.Read(true)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", x)
+		assert.Equal(t, 2, buf.Position())
+		assert.True(t, buf.IsEOF())
+
+		_, err = buf.Read(true)
+		assert.ErrorIs(t, err, common.ErrEndOfFile)
+
+		assert.NoError(t, buf.Seek(2))
+		assert.ErrorIs(t, buf.Seek(3), common.ErrOutOfBounds)
+		assert.ErrorIs(t, buf.Seek(-1), common.ErrOutOfBounds)
+		assert.Equal(t, 2, buf.Position())
+
+		assert.NoError(t, buf.Seek(1))
+		assert.Equal(t, 1, buf.Position())
+		assert.False(t, buf.IsEOF())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		buf := Buffer([]int{})
+		assert.True(t, buf.IsEOF())
+		assert.NoError(t, buf.Seek(0))
+		assert.ErrorIs(t, buf.Seek(1), common.ErrOutOfBounds)
+
+		_, err := buf.Read(false)
+		assert.ErrorIs(t, err, common.ErrEndOfFile)
+
+		spans := BufferWithSpans([]int{}, func(x int) int { return x })
+		assert.Equal(t, NewPosition(0, 0), spans.Position())
+	})
+
+	t.Run("span positions", func(t *testing.T) {
+		t.Parallel()
+
+		data := []testToken{
+			{kind: testIdent, text: "x", offset: 0},
+			{kind: testAssign, text: "=", offset: 2},
+			{kind: testNumber, text: "1", offset: 4},
+		}
+
+		buf := BufferWithSpans(data, testToken.Offset)
+		assert.Equal(t, NewPosition(0, 0), buf.Position())
+
+		_, err := buf.Read(true)
+		assert.NoError(t, err)
+		assert.Equal(t, NewPosition(1, 2), buf.Position())
+		assert.Equal(t, 1, buf.Position().Index())
+		assert.Equal(t, 2, buf.Position().Span())
+		assert.Equal(t, "token=1 span=2", buf.Position().String())
+
+		assert.ErrorIs(t, buf.Seek(NewPosition(3, 0)), common.ErrOutOfBounds)
+
+		_, err = buf.Read(true)
+		assert.NoError(t, err)
+		_, err = buf.Read(true)
+		assert.NoError(t, err)
+		assert.True(t, buf.IsEOF())
+		assert.Equal(t, NewPosition(3, 4), buf.Position())
+
+		assert.NoError(t, buf.Seek(NewPosition(0, 0)))
+		assert.Equal(t, NewPosition(0, 0), buf.Position())
+	})
+}
@@ -0,0 +1,80 @@
+package tokens
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// Token - token with kind, for example token produced by lexer.
+type Token[K comparable] interface {
+	Kind() K
+}
+
+// Satisfy - succeeds for any token for which the supplied function f returns true.
+// Returns the token that is actually readed from input buffer.
+// Greedy by default - keep position after reading.
+func Satisfy[T any, P any](
+	errMessage string,
+	f common.Condition[T],
+) common.Combinator[T, P, T] {
+	return common.Satisfy[T, P](errMessage, true, f)
+}
+
+// Eq - succeeds for any token of kind k.
+// Returns the token that is actually readed from input buffer.
+// Greedy by default - keep position after reading.
+func Eq[T Token[K], P any, K comparable](
+	errMessage string,
+	k K,
+) common.Combinator[T, P, T] {
+	return common.Satisfy[T, P](errMessage, true, func(x T) bool {
+		return x.Kind() == k
+	})
+}
+
+// NotEq - succeeds for any token which kind not equal k.
+// Returns the token that is actually readed from input buffer.
+// Greedy by default - keep position after reading.
+func NotEq[T Token[K], P any, K comparable](
+	errMessage string,
+	k K,
+) common.Combinator[T, P, T] {
+	return common.Satisfy[T, P](errMessage, true, func(x T) bool {
+		return x.Kind() != k
+	})
+}
+
+// OneOf - succeeds for any token which kind included in input kinds.
+// Returns the token that is actually readed from input buffer.
+// Greedy by default - keep position after reading.
+func OneOf[T Token[K], P any, K comparable](
+	errMessage string,
+	kinds ...K,
+) common.Combinator[T, P, T] {
+	m := make(map[K]struct{}, len(kinds))
+	for _, k := range kinds {
+		m[k] = struct{}{}
+	}
+
+	return common.Satisfy[T, P](errMessage, true, func(x T) bool {
+		_, exists := m[x.Kind()]
+		return exists
+	})
+}
+
+// NoneOf - succeeds for any token which kind not included in input kinds.
+// Returns the token that is actually readed from input buffer.
+// Greedy by default - keep position after reading.
+func NoneOf[T Token[K], P any, K comparable](
+	errMessage string,
+	kinds ...K,
+) common.Combinator[T, P, T] {
+	m := make(map[K]struct{}, len(kinds))
+	for _, k := range kinds {
+		m[k] = struct{}{}
+	}
+
+	return common.Satisfy[T, P](errMessage, true, func(x T) bool {
+		_, exists := m[x.Kind()]
+		return !exists
+	})
+}
@@ -0,0 +1,145 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/okneniz/parsec/common"
+)
+
+type (
+	testKind int
+
+	testToken struct {
+		kind   testKind
+		text   string
+		offset int
+	}
+)
+
+const (
+	testIdent testKind = iota
+	testNumber
+	testAssign
+	testSemicolon
+)
+
+func (t testToken) Kind() testKind {
+	return t.kind
+}
+
+func (t testToken) Offset() int {
+	return t.offset
+}
+
+func TestComparable(t *testing.T) {
+	t.Parallel()
+
+	x := testToken{kind: testIdent, text: "x", offset: 0}
+	eq := testToken{kind: testAssign, text: "=", offset: 2}
+	one := testToken{kind: testNumber, text: "1", offset: 4}
+	semi := testToken{kind: testSemicolon, text: ";", offset: 5}
+
+	input := []testToken{x, eq, one, semi}
+
+	t.Run("Satisfy", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Satisfy[testToken, int]("expected x", func(tok testToken) bool {
+			return tok.text == "x"
+		})
+
+		result, err := Parse(input, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, x, result)
+
+		_, err = Parse(input[1:], comb)
+		assert.Equal(t, common.NewParseError(0, "expected x"), err)
+	})
+
+	t.Run("Eq", func(t *testing.T) {
+		t.Parallel()
+
+		comb := Eq[testToken, int]("expected identifier", testIdent)
+
+		result, err := Parse(input, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, x, result)
+
+		_, err = Parse(input[1:], comb)
+		assert.Equal(t, common.NewParseError(0, "expected identifier"), err)
+
+		_, err = Parse([]testToken{}, comb)
+		assert.Equal(t, common.NewParseError(0, "expected identifier"), err)
+	})
+
+	t.Run("NotEq", func(t *testing.T) {
+		t.Parallel()
+
+		comb := NotEq[testToken, int]("unexpected identifier", testIdent)
+
+		result, err := Parse(input[1:], comb)
+		assert.NoError(t, err)
+		assert.Equal(t, eq, result)
+
+		_, err = Parse(input, comb)
+		assert.Equal(t, common.NewParseError(0, "unexpected identifier"), err)
+	})
+
+	t.Run("OneOf", func(t *testing.T) {
+		t.Parallel()
+
+		comb := OneOf[testToken, int]("expected operand", testIdent, testNumber)
+
+		result, err := Parse(input[2:], comb)
+		assert.NoError(t, err)
+		assert.Equal(t, one, result)
+
+		_, err = Parse(input[1:], comb)
+		assert.Equal(t, common.NewParseError(0, "expected operand"), err)
+	})
+
+	t.Run("NoneOf", func(t *testing.T) {
+		t.Parallel()
+
+		comb := NoneOf[testToken, int]("unexpected operand", testIdent, testNumber)
+
+		result, err := Parse(input[1:], comb)
+		assert.NoError(t, err)
+		assert.Equal(t, eq, result)
+
+		_, err = Parse(input, comb)
+		assert.Equal(t, common.NewParseError(0, "unexpected operand"), err)
+	})
+
+	t.Run("statement with spans", func(t *testing.T) {
+		t.Parallel()
+
+		type pos = Position[int]
+
+		comb := common.SkipAfter(
+			Eq[testToken, pos]("expected ;", testSemicolon),
+			common.And(
+				Eq[testToken, pos]("expected identifier", testIdent),
+				common.Skip(
+					Eq[testToken, pos]("expected =", testAssign),
+					Eq[testToken, pos]("expected number", testNumber),
+				),
+				func(name, value testToken) string {
+					return name.text + "=" + value.text
+				},
+			),
+		)
+
+		result, err := ParseWithSpans(input, testToken.Offset, comb)
+		assert.NoError(t, err)
+		assert.Equal(t, "x=1", result)
+
+		_, err = ParseWithSpans(input[:3], testToken.Offset, comb)
+		assert.Equal(t, common.NewParseError(NewPosition(3, 4), "expected ;"), err)
+
+		_, err = ParseWithSpans([]testToken{x, eq, semi}, testToken.Offset, comb)
+		assert.Equal(t, common.NewParseError(NewPosition(2, 5), "expected number"), err)
+	})
+}
@@ -0,0 +1,25 @@
+package tokens
+
+import (
+	"github.com/okneniz/parsec/common"
+)
+
+// Parse - parse tokens by c combinator, index of token is used as position.
+func Parse[T any, S any](
+	data []T,
+	parse common.Combinator[T, int, S],
+) (S, common.Error[int]) {
+	buf := Buffer(data)
+	return common.Parse[T, int, S](buf, parse)
+}
+
+// ParseWithSpans - parse tokens by c combinator,
+// index and span of token is used as position.
+func ParseWithSpans[T any, P any, S any](
+	data []T,
+	span func(T) P,
+	parse common.Combinator[T, Position[P], S],
+) (S, common.Error[Position[P]]) {
+	buf := BufferWithSpans(data, span)
+	return common.Parse[T, Position[P], S](buf, parse)
+}
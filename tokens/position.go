@@ -0,0 +1,32 @@
+package tokens
+
+import (
+	"fmt"
+)
+
+// Position - position in tokens stream,
+// index of token and its span in the source (for example strings.Position).
+type Position[S any] struct {
+	index int
+	span  S
+}
+
+// NewPosition - make position of token.
+func NewPosition[S any](index int, span S) Position[S] {
+	return Position[S]{index: index, span: span}
+}
+
+// Index - index of token.
+func (p Position[S]) Index() int {
+	return p.index
+}
+
+// Span - span of token in the source.
+func (p Position[S]) Span() S {
+	return p.span
+}
+
+// String - return string representation of position.
+func (p Position[S]) String() string {
+	return fmt.Sprintf("token=%d span=%v", p.index, p.span)
+}